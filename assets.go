@@ -0,0 +1,58 @@
+// Package goform предоставляет встроенные шаблоны и статические файлы библиотеки.
+// Основной API находится в пакете core, интеграции с фреймворками — в пакетах адаптеров.
+package goform
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// Templates возвращает встроенные шаблоны (default.html) для core.NewTemplateRendererFS.
+func Templates() fs.FS {
+	sub, _ := fs.Sub(templatesFS, "templates")
+	return sub
+}
+
+// Static возвращает встроенные статические файлы (js/ajax.js).
+func Static() fs.FS {
+	sub, _ := fs.Sub(staticFS, "static")
+	return sub
+}
+
+// StaticHandler возвращает http.Handler, раздающий встроенные статические файлы
+// с заголовками Cache-Control и ETag. Путь запроса отсчитывается от корня директории static,
+// поэтому обработчик обычно подключается через http.StripPrefix:
+//
+//	http.Handle("/static/", http.StripPrefix("/static/", goform.StaticHandler(24*time.Hour)))
+func StaticHandler(maxAge time.Duration) http.Handler {
+	static := Static()
+	cacheControl := "public, max-age=" + strconv.Itoa(int(maxAge.Seconds()))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		data, err := fs.ReadFile(static, name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		sum := sha256.Sum256(data)
+		w.Header().Set("Cache-Control", cacheControl)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:8])+`"`)
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+	})
+}
@@ -0,0 +1,59 @@
+package goform
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplates(t *testing.T) {
+	data, err := fs.ReadFile(Templates(), "default.html")
+	if err != nil {
+		t.Fatalf("Expected embedded default.html, got error: %v", err)
+	}
+	if !strings.Contains(string(data), `<meta name="csrf-token"`) {
+		t.Error("Expected default.html to contain CSRF meta tag")
+	}
+}
+
+func TestStaticHandler(t *testing.T) {
+	handler := http.StripPrefix("/static/", StaticHandler(time.Hour))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/static/js/ajax.js", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if rec.Header().Get("Cache-Control") != "public, max-age=3600" {
+		t.Errorf("Unexpected Cache-Control '%s'", rec.Header().Get("Cache-Control"))
+	}
+	if !strings.Contains(rec.Header().Get("Content-Type"), "javascript") {
+		t.Errorf("Unexpected Content-Type '%s'", rec.Header().Get("Content-Type"))
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected ETag header")
+	}
+
+	// Повторный запрос с ETag получает 304
+	req := httptest.NewRequest(http.MethodGet, "/static/js/ajax.js", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("Expected status 304, got %d", rec.Code)
+	}
+
+	// Файлы вне static недоступны
+	for _, path := range []string{"/static/missing.js", "/static/../templates/default.html", "/static/js"} {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for %s, got %d", path, rec.Code)
+		}
+	}
+}
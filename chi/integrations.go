@@ -0,0 +1,64 @@
+package chi
+
+import (
+	"github.com/DBenyukh/goform/core"
+	"github.com/DBenyukh/goform/nethttp"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+// Chi использует стандартные обработчики net/http, поэтому пакет переиспользует адаптер nethttp.
+
+// ModelFormMiddleware возвращает middleware, создающее новую модель и форму для каждого запроса.
+// T — тип структуры модели, newModel возвращает указатель на новый экземпляр.
+// Поля с тегом source:"path" получают параметры маршрута chi, поэтому middleware
+// следует подключать к маршруту (With, Route), а не к корневому маршрутизатору.
+func ModelFormMiddleware[T any](newModel func() *T, method, formID string) func(http.Handler) http.Handler {
+	bind := nethttp.ModelFormMiddleware(newModel, method, formID)
+	return func(next http.Handler) http.Handler {
+		handler := bind(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.ServeHTTP(w, core.WithPathParamExtractor(r, PathParam))
+		})
+	}
+}
+
+// PathParam извлекает параметр пути маршрута chi для полей с тегом source:"path".
+func PathParam(r *http.Request, name string) string {
+	return chi.URLParam(r, name)
+}
+
+// GetForm возвращает форму и модель *T текущего запроса.
+// Последнее значение равно false, если middleware не был вызван или тип модели не совпадает.
+func GetForm[T any](r *http.Request) (*core.Form, *T, bool) {
+	return nethttp.GetForm[T](r)
+}
+
+// CSRFMiddleware возвращает middleware для проверки CSRF-токена.
+func CSRFMiddleware() func(http.Handler) http.Handler {
+	return nethttp.CSRFMiddleware()
+}
+
+// Render рендерит шаблон name (пустое имя — шаблон по умолчанию) с буферизацией:
+// при ошибке шаблона клиент получает ответ со статусом 500 вместо обрезанной страницы.
+func Render(w http.ResponseWriter, r *http.Request, renderer *core.TemplateRenderer, name string, data any) error {
+	return nethttp.Render(w, r, renderer, name, data)
+}
+
+// RenderForm рендерит форму шаблоном по умолчанию.
+// CSRF-токен берется из запроса или генерируется автоматически, cookie обновляется.
+func RenderForm(w http.ResponseWriter, r *http.Request, renderer *core.TemplateRenderer, form *core.Form) error {
+	return nethttp.RenderForm(w, r, renderer, form)
+}
+
+// Respond отвечает данными формы в формате, выбранном core.Negotiate по заголовкам запроса,
+// со статусом 422 при ошибках валидации и 200 в остальных случаях.
+// HTML рендерится шаблоном по умолчанию renderer, а если renderer равен nil — встроенным шаблоном формы.
+func Respond(w http.ResponseWriter, r *http.Request, renderer *core.TemplateRenderer, form *core.Form) error {
+	return nethttp.Respond(w, r, renderer, form)
+}
+
+// AddCustomValidationMiddleware возвращает middleware для добавления кастомных правил валидации.
+func AddCustomValidationMiddleware(fieldName string, fn core.ValidationFunc) func(http.Handler) http.Handler {
+	return nethttp.AddCustomValidationMiddleware(fieldName, fn)
+}
@@ -0,0 +1,147 @@
+package chi
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DBenyukh/goform/core"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type TestForm struct {
+	Username string `form:"username" validate:"required,min=3" validate_msg:"Username must be at least 3 characters"`
+	Email    string `form:"email" validate:"required,email" validate_msg:"Please provide a valid email address"`
+	Password string `form:"password" validate:"required" validate_msg:"Password is required"`
+}
+
+func newFormRequest(data url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// TestModelFormMiddleware проверяет привязку данных формы в маршрутизаторе chi.
+func TestModelFormMiddleware(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(ModelFormMiddleware(func() *TestForm { return &TestForm{} }, http.MethodPost, "test_form"))
+	r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+		_, model, ok := GetForm[TestForm](r)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(model)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, newFormRequest(url.Values{"test_form_username": {"testuser"}}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var responseData TestForm
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseData))
+	assert.Equal(t, "testuser", responseData.Username)
+}
+
+// TestCSRFMiddleware проверяет корректность работы CSRFMiddleware.
+func TestCSRFMiddleware(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(CSRFMiddleware())
+	r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, newFormRequest(url.Values{}))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req := newFormRequest(url.Values{"csrf_token": {"token123"}})
+	req.AddCookie(&http.Cookie{Name: core.CSRFCookieName, Value: "token123"})
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestRenderForm проверяет рендеринг формы с автоматическим CSRF-токеном.
+func TestRenderForm(t *testing.T) {
+	renderer, err := core.NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		form := core.NewForm(&TestForm{}, http.MethodPost, "test_form")
+		form.RenderHTML = true
+		assert.NoError(t, RenderForm(w, r, renderer, form))
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "test_form_username")
+	assert.Len(t, rec.Result().Cookies(), 1)
+}
+
+// TestAddCustomValidationMiddleware проверяет добавление кастомных правил валидации.
+func TestAddCustomValidationMiddleware(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(ModelFormMiddleware(func() *TestForm { return &TestForm{} }, http.MethodPost, "test_form"))
+	r.Use(AddCustomValidationMiddleware("username", func(value string) error {
+		if len(value) < 5 {
+			return errors.New("username too short")
+		}
+		return nil
+	}))
+	r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+		form, model, _ := GetForm[TestForm](r)
+		if err := form.Validate(model); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	formData := url.Values{
+		"test_form_username": {"user"},
+		"test_form_email":    {"test@example.com"},
+		"test_form_password": {"password"},
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, newFormRequest(formData))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	formData.Set("test_form_username", "longusername")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, newFormRequest(formData))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestModelFormMiddlewarePathParams проверяет привязку параметров маршрута chi.
+func TestModelFormMiddlewarePathParams(t *testing.T) {
+	type PathForm struct {
+		ID    int    `form:"id" source:"path"`
+		Query string `form:"q" source:"query"`
+	}
+
+	r := chi.NewRouter()
+	r.With(ModelFormMiddleware(func() *PathForm { return &PathForm{} }, http.MethodGet, "search")).
+		Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			_, model, _ := GetForm[PathForm](r)
+			json.NewEncoder(w).Encode(model)
+		})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42?q=golang", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var responseData PathForm
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseData))
+	assert.Equal(t, PathForm{ID: 42, Query: "golang"}, responseData)
+}
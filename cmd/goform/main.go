@@ -0,0 +1,67 @@
+// Команда goform генерирует код форм без рефлексии по структурам с тегами form.
+//
+//	goform gen -type Signup[,Profile] [-output file] [dir]
+//
+// Удобно вызывать из go generate в файле с моделью:
+//
+//	//go:generate go run github.com/DBenyukh/goform/cmd/goform gen -type Signup
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DBenyukh/goform/gen"
+)
+
+const usage = `usage: goform gen -type T[,T2...] [-output file] [dir]
+
+Generates reflection-free binding, validation and rendering code for structs with form tags.
+Exported fields without a form tag become hidden fields, as with core.NewForm; unexported
+fields and validation rules require a form tag, so mark such fields form:"-".
+`
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "gen" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err := runGen(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "goform gen:", err)
+		os.Exit(1)
+	}
+}
+
+// runGen разбирает флаги команды gen, генерирует код и записывает его в файл.
+func runGen(args []string) error {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	typeNames := flags.String("type", "", "comma-separated list of struct type names")
+	output := flags.String("output", "", "output file name (default <type>_goform.go in dir)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *typeNames == "" {
+		flags.Usage()
+		return fmt.Errorf("-type is required")
+	}
+
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+	src, err := gen.Generate(dir, types...)
+	if err != nil {
+		return err
+	}
+
+	path := *output
+	if path == "" {
+		path = filepath.Join(dir, gen.OutputName(types[0]))
+	}
+	return os.WriteFile(path, src, 0o644)
+}
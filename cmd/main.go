@@ -3,12 +3,14 @@ package main
 import (
 	"encoding/json"
 	"errors"
+	"github.com/DBenyukh/goform"
 	"github.com/DBenyukh/goform/core"
-	"html/template"
+	"github.com/DBenyukh/goform/nethttp"
 	"log"
 	"net/http"
-	"path/filepath"
+	"os"
 	"strings"
+	"time"
 )
 
 // RegistrationForm представляет форму регистрации.
@@ -20,21 +22,20 @@ type RegistrationForm struct {
 	FormID   string `form:"-"`
 }
 
-var tmpl *template.Template
+var renderer *core.TemplateRenderer
 
 func init() {
-	projectDir, err := filepath.Abs(".")
-	if err != nil {
-		log.Fatalf("Error getting absolute project directory path: %v", err)
-	}
+	// Шаблоны из директории templates (если она есть) перекрывают встроенные
+	templates := core.LayeredFS(os.DirFS("templates"), goform.Templates())
 
-	templateDir := filepath.Join(projectDir, "templates")
-	renderer, err := core.NewTemplateRenderer(templateDir, "") // Указываем имя шаблона
+	var err error
+	renderer, err = core.NewTemplateRendererFS(templates, "default.html") // Указываем имя шаблона
 	if err != nil {
 		log.Fatalf("Failed to create template renderer: %v", err)
 	}
 
-	tmpl = renderer.Templates
+	// GOFORM_DEV=1 включает перезагрузку шаблонов при изменении файлов
+	renderer.DevMode = os.Getenv("GOFORM_DEV") != ""
 }
 
 func isPasswordStrong(password string) error {
@@ -60,10 +61,6 @@ func isPasswordStrong(password string) error {
 	return nil
 }
 
-func isAjax(r *http.Request) bool {
-	return r.Header.Get("X-Requested-With") == "XMLHttpRequest"
-}
-
 func main() {
 	http.HandleFunc("/register", func(w http.ResponseWriter, r *http.Request) {
 		model := &RegistrationForm{
@@ -71,62 +68,23 @@ func main() {
 			FormID: "register_form",
 		}
 		form := core.NewForm(model, model.Method, model.FormID)
-		form.RenderHTML = true // Устанавливаем флаг (true для монолита, false для разделённого фронта/бэка)
 
 		// Добавление кастомного правила валидации
 		form.AddCustomValidation("password", isPasswordStrong)
 
 		if r.Method == http.MethodGet {
-			token, err := core.GenerateCSRFToken()
-			if err != nil {
-				http.Error(w, "Failed to generate CSRF token", http.StatusInternalServerError)
-				return
-			}
-
-			form.AddCSRFToken(token)
-
-			http.SetCookie(w, &http.Cookie{
-				Name:     "csrf_token",
-				Value:    token,
-				HttpOnly: true,
-				Path:     "/",
-			})
-
-			// Возвращаем данные в зависимости от флага
-			if form.RenderHTML {
-				_ = tmpl.ExecuteTemplate(w, "default.html", form.ToResponse())
-			} else {
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(form.ToResponse())
+			// Браузер получает HTML, AJAX- и API-клиенты — JSON; при ошибке рендерер сам отвечает статусом 500
+			if err := renderer.Respond(w, r, form); err != nil {
+				log.Println("Failed to render form:", err)
 			}
 			return
 		}
 
-		method := r.Method
-		if r.Method == http.MethodPost {
-			if err := r.ParseForm(); err != nil {
-				http.Error(w, "Failed to parse form", http.StatusBadRequest)
-				return
-			}
-
-			methodOverride := r.FormValue("_method")
-			if methodOverride != "" {
-				method = methodOverride
-			}
-		}
-
-		if method == http.MethodPost {
-			csrfTokenFromForm := r.FormValue(model.FormID + "_csrf_token")
-			csrfTokenFromCookie, err := r.Cookie("csrf_token")
-			if err != nil {
-				http.Error(w, "CSRF token missing in cookies", http.StatusForbidden)
-				return
-			}
-
-			if csrfTokenFromCookie.Value != csrfTokenFromForm {
-				http.Error(w, "Invalid CSRF token", http.StatusForbidden)
-				return
-			}
+		// Метод из поля _method уже подставлен MethodOverrideMiddleware;
+		// токен из заголовка X-CSRF-Token или поля формы сравнивается с cookie за постоянное время
+		if err := core.VerifyCSRFToken(r); err != nil {
+			http.Error(w, "Invalid CSRF token", http.StatusForbidden)
+			return
 		}
 
 		if err := form.Bind(r); err != nil {
@@ -134,61 +92,27 @@ func main() {
 			return
 		}
 
-		for _, field := range form.Fields {
-			field.Value = r.FormValue(model.FormID + "_" + field.Name)
+		if err := core.UpdateModelFromForm(model, form); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
 		}
 
-		model.Username = r.FormValue(model.FormID + "_username")
-		model.Email = r.FormValue(model.FormID + "_email")
-		model.Password = r.FormValue(model.FormID + "_password")
-
 		if err := form.Validate(model); err != nil {
-			if isAjax(r) {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				errors := make(map[string]string)
-				for _, field := range form.Fields {
-					if field.Error != "" {
-						errors[field.Name] = field.Error
-					}
-				}
-				json.NewEncoder(w).Encode(map[string]interface{}{
-					"errors": errors,
-				})
-				return
+			// Ошибки валидации отправляются со статусом 422 в формате, который ожидает клиент
+			if err := renderer.Respond(w, r, form); err != nil {
+				log.Println("Failed to render form:", err)
 			}
-
-			// Генерация нового CSRF-токена
-			newToken, err := core.GenerateCSRFToken()
-			if err != nil {
-				http.Error(w, "Failed to generate CSRF token", http.StatusInternalServerError)
-				return
-			}
-
-			// Обновление CSRF-токена в форме
-			form.AddCSRFToken(newToken)
-
-			// Установка нового CSRF-токена в куки
-			http.SetCookie(w, &http.Cookie{
-				Name:     "csrf_token",
-				Value:    newToken,
-				HttpOnly: true,
-				Path:     "/",
-			})
-
-			// Рендеринг формы с новым CSRF-токеном
-			_ = tmpl.ExecuteTemplate(w, "default.html", form.ToResponse())
 			return
 		}
 
-		if isAjax(r) {
+		if core.Negotiate(r) == core.FormatJSON {
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(map[string]string{"message": "User registered successfully!"})
 			return
 		}
 
-		switch method {
+		switch r.Method {
 		case http.MethodPost:
 			w.Write([]byte("User registered successfully!"))
 		case http.MethodPut:
@@ -200,8 +124,8 @@ func main() {
 		}
 	})
 
-	fs := http.FileServer(http.Dir("static"))
-	http.Handle("/static/", http.StripPrefix("/static/", fs))
+	http.Handle("/static/", http.StripPrefix("/static/", goform.StaticHandler(24*time.Hour)))
 
-	http.ListenAndServe(":8080", nil)
+	// PUT и DELETE из HTML-форм приходят как POST с полем _method
+	http.ListenAndServe(":8080", nethttp.MethodOverrideMiddleware()(http.DefaultServeMux))
 }
@@ -1,22 +1,163 @@
 package core
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strings"
 )
 
+// BodyDecoder декодирует тело запроса в значения полей по их именам.
+// Значения должны иметь типы, совместимые с encoding/json: string, bool, числа,
+// nil, []interface{} и map[string]interface{}.
+type BodyDecoder func(body io.Reader) (map[string]interface{}, error)
+
+// DefaultMaxBodySize — ограничение размера тела запроса по умолчанию (10 МБ, как у http.Request.ParseForm).
+const DefaultMaxBodySize int64 = 10 << 20
+
+// maxBodySize — наибольший размер тела запроса, который читают декодеры.
+var maxBodySize = DefaultMaxBodySize
+
+// SetMaxBodySize задает наибольший размер тела запроса в байтах для привязки данных.
+// Тело большего размера приводит к ошибке *http.MaxBytesError. Значение n <= 0
+// восстанавливает ограничение по умолчанию DefaultMaxBodySize.
+func SetMaxBodySize(n int64) {
+	if n <= 0 {
+		n = DefaultMaxBodySize
+	}
+	maxBodySize = n
+}
+
+// bodyDecoders содержит декодеры тела запроса по типам содержимого.
+var bodyDecoders = map[string]BodyDecoder{
+	"application/json": decodeJSONBody,
+}
+
+// RegisterBodyDecoder регистрирует декодер тела запроса для типа содержимого,
+// например application/x-msgpack. Регистрацию следует выполнять при инициализации программы.
+func RegisterBodyDecoder(mediaType string, decoder BodyDecoder) {
+	bodyDecoders[mediaType] = decoder
+}
+
+// lookupBodyDecoder возвращает декодер для заголовка Content-Type.
+// Типы с суффиксом +json (например application/merge-patch+json) декодируются как JSON.
+func lookupBodyDecoder(contentType string) (string, BodyDecoder, bool) {
+	if contentType == "" {
+		return "", nil, false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", nil, false
+	}
+	if decoder, ok := bodyDecoders[mediaType]; ok {
+		return mediaType, decoder, true
+	}
+	if strings.HasSuffix(mediaType, "+json") {
+		return mediaType, bodyDecoders["application/json"], true
+	}
+	return "", nil, false
+}
+
 // bindForm привязывает данные из запроса к форме.
-func bindForm(r *http.Request, form *Form) error {
+// Тело с зарегистрированным типом содержимого (по умолчанию JSON) декодируется декодером,
+// остальные запросы разбираются как HTML-формы. Поля с тегом source получают значения
+// из строки запроса, параметров пути, заголовков или cookie.
+// Имена переданных полей запоминаются; при partial поля, которых нет в запросе, не меняются.
+// Тело запроса ограничивается размером из SetMaxBodySize.
+func bindForm(r *http.Request, form *Form, partial bool) error {
+	form.submitted = make(map[string]bool)
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = http.MaxBytesReader(nil, r.Body, maxBodySize)
+	}
+	if mediaType, decoder, ok := lookupBodyDecoder(r.Header.Get("Content-Type")); ok {
+		if err := bindBody(r, form, mediaType, decoder, partial); err != nil {
+			return err
+		}
+		return bindSources(r, form, partial)
+	}
+
 	err := r.ParseForm()
 	if err != nil {
 		return err
 	}
 
 	for _, field := range form.Fields {
+		source, name := parseSource(field)
+		if source != "" && source != SourceForm {
+			continue
+		}
 		// Учитываем FormID при извлечении значений
-		key := form.FormID + "_" + field.Name
-		value := r.FormValue(key)
-		field.Value = value // Устанавливаем значение поля
+		key := form.InputName(name)
+		values := r.Form
+		if source == SourceForm {
+			values = r.PostForm // Только тело, без строки запроса
+		}
+		_, ok := values[key]
+		form.setSubmitted(field, values.Get(key), ok, partial)
 	}
 
+	return bindSources(r, form, partial)
+}
+
+// bindBody привязывает к форме значения, декодированные из тела запроса.
+// Ключами служат имена полей; имена элементов HTML-формы (Form.InputName) тоже принимаются.
+// Скалярные значения сохраняются строками, как у HTML-форм, вложенные объекты и массивы — как есть.
+func bindBody(r *http.Request, form *Form, mediaType string, decoder BodyDecoder, partial bool) error {
+	if r.Body == nil {
+		return fmt.Errorf("decode %s body: empty body", mediaType)
+	}
+	values, err := decoder(r.Body)
+	if err != nil {
+		return fmt.Errorf("decode %s body: %w", mediaType, err)
+	}
+
+	for _, field := range form.Fields {
+		if !fromBody(field) {
+			continue
+		}
+		_, name := parseSource(field)
+		value, ok := values[name]
+		if !ok {
+			value, ok = values[form.InputName(name)]
+		}
+		form.setSubmitted(field, bodyValue(value), ok, partial)
+	}
 	return nil
 }
+
+// bodyValue приводит декодированное значение к виду, который используют поля формы.
+// Ложное булево значение становится пустой строкой, как неотмеченный чекбокс.
+func bodyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "true"
+		}
+		return ""
+	case []interface{}, map[string]interface{}:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// decodeJSONBody декодирует JSON-объект. Числа сохраняются без потери точности.
+func decodeJSONBody(body io.Reader) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return nil, fmt.Errorf("expected JSON object")
+	}
+	return values, nil
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Address struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type JSONBodyForm struct {
+	Username string   `form:"username" validate:"required,min=3" validate_msg:"Username must be at least 3 characters"`
+	Age      int      `form:"age"`
+	Agree    bool     `form:"agree"`
+	Address  Address  `form:"address"`
+	Tags     []string `form:"tags" validate:"required" validate_msg:"Tags are required"`
+}
+
+func newJSONRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return req
+}
+
+func TestBindJSONBody(t *testing.T) {
+	model := &JSONBodyForm{}
+	req := newJSONRequest(`{
+		"username": "john",
+		"age": 42,
+		"agree": true,
+		"address": {"city": "Moscow", "street": "Tverskaya"},
+		"tags": ["go", "forms"]
+	}`)
+
+	form, err := BindModel(req, model, http.MethodPost, "profile")
+	if err != nil {
+		t.Fatalf("BindModel failed: %v", err)
+	}
+
+	expected := &JSONBodyForm{
+		Username: "john",
+		Age:      42,
+		Agree:    true,
+		Address:  Address{City: "Moscow", Street: "Tverskaya"},
+		Tags:     []string{"go", "forms"},
+	}
+	if !reflect.DeepEqual(model, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, model)
+	}
+	if err := form.Validate(model); err != nil {
+		t.Errorf("Expected no validation errors, got %v", form.Errs)
+	}
+}
+
+func TestBindJSONBodyValidation(t *testing.T) {
+	model := &JSONBodyForm{}
+	// Ключи с префиксом FormID принимаются так же, как в HTML-форме
+	req := newJSONRequest(`{"profile_username": "jo", "agree": false, "tags": []}`)
+
+	form, err := BindModel(req, model, http.MethodPost, "profile")
+	if err != nil {
+		t.Fatalf("BindModel failed: %v", err)
+	}
+	if model.Username != "jo" || model.Agree {
+		t.Errorf("Unexpected model: %+v", model)
+	}
+
+	if err := form.Validate(model); err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	if form.Errs["username"] != "Username must be at least 3 characters" {
+		t.Errorf("Expected username error, got %v", form.Errs)
+	}
+	if form.Errs["tags"] != "Tags are required" {
+		t.Errorf("Expected empty array to fail required, got %v", form.Errs)
+	}
+}
+
+func TestBindJSONBodyErrors(t *testing.T) {
+	for _, body := range []string{`{"username":`, `["john"]`, `null`} {
+		form := NewForm(&JSONBodyForm{}, http.MethodPost, "profile")
+		if err := form.Bind(newJSONRequest(body)); err == nil {
+			t.Errorf("Expected error for body %s", body)
+		}
+	}
+
+	// Строка вместо числа приводит к ошибке обновления модели
+	if _, err := BindModel(newJSONRequest(`{"age": "old"}`), &JSONBodyForm{}, http.MethodPost, "profile"); err == nil {
+		t.Error("Expected error for invalid number")
+	}
+}
+
+func TestBindBodySizeLimit(t *testing.T) {
+	SetMaxBodySize(32)
+	defer SetMaxBodySize(0)
+
+	body := `{"username": "` + strings.Repeat("a", 64) + `"}`
+	form := NewForm(&JSONBodyForm{}, http.MethodPost, "profile")
+	err := form.Bind(newJSONRequest(body))
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(err, &maxBytesErr) {
+		t.Fatalf("Expected MaxBytesError, got %v", err)
+	}
+
+	// Ограничение действует и на тела HTML-форм
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("profile_username="+strings.Repeat("a", 64)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := NewForm(&JSONBodyForm{}, http.MethodPost, "profile").Bind(req); err == nil {
+		t.Error("Expected error for oversized form body")
+	}
+
+	// Тело в пределах ограничения привязывается
+	form = NewForm(&JSONBodyForm{}, http.MethodPost, "profile")
+	if err := form.Bind(newJSONRequest(`{"username": "john"}`)); err != nil {
+		t.Errorf("Bind failed: %v", err)
+	}
+}
+
+func TestRegisterBodyDecoder(t *testing.T) {
+	defer delete(bodyDecoders, "text/x-lines")
+
+	// Простой декодер строк вида key=value вместо настоящего формата, например msgpack
+	RegisterBodyDecoder("text/x-lines", func(body io.Reader) (map[string]interface{}, error) {
+		values := make(map[string]interface{})
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			key, value, _ := strings.Cut(scanner.Text(), "=")
+			values[key] = value
+		}
+		return values, scanner.Err()
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("username=john\nage=7"))
+	req.Header.Set("Content-Type", "text/x-lines")
+	model := &JSONBodyForm{}
+	if _, err := BindModel(req, model, http.MethodPost, "profile"); err != nil {
+		t.Fatalf("BindModel failed: %v", err)
+	}
+	if model.Username != "john" || model.Age != 7 {
+		t.Errorf("Unexpected model: %+v", model)
+	}
+}
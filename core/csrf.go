@@ -3,10 +3,35 @@ package core
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"errors"
+	"net/http"
 )
 
+const (
+	CSRFCookieName = "csrf_token"   // Имя cookie для CSRF-токена
+	CSRFHeaderName = "X-CSRF-Token" // Заголовок, в котором AJAX-запросы передают CSRF-токен
+	CSRFFieldName  = "csrf_token"   // Имя поля формы с CSRF-токеном
+	CSRFNameField  = "csrf_field"   // Скрытое поле с именем поля CSRF-токена, выбранным стратегией имен формы
+)
+
+var (
+	ErrCSRFTokenNotFound = errors.New("CSRF token not found")   // В cookies нет CSRF-токена
+	ErrCSRFTokenRequired = errors.New("CSRF token is required") // Запрос не передал CSRF-токен
+	ErrInvalidCSRFToken  = errors.New("invalid CSRF token")     // Токены в cookie и запросе не совпадают
+)
+
+// secureCookies — признак Secure у cookie, которые устанавливает goform.
+var secureCookies = true
+
+// SetSecureCookies задает признак Secure у cookie goform. По умолчанию он установлен, и браузер
+// отправляет cookie только по HTTPS, в том числе когда TLS завершается на прокси.
+// Отключайте его только для разработки по HTTP на адресе, отличном от localhost.
+func SetSecureCookies(secure bool) {
+	secureCookies = secure
+}
+
 // generateCSRFToken генерирует CSRF-токен с использованием SHA-256.
 // Возвращает токен в виде строки base64 или ошибку, если что-то пошло не так.
 func GenerateCSRFToken() (string, error) {
@@ -20,3 +45,88 @@ func GenerateCSRFToken() (string, error) {
 	token := base64.StdEncoding.EncodeToString(hash[:])
 	return token, nil
 }
+
+// EnsureCSRFToken получает CSRF-токен из cookie запроса или генерирует новый,
+// обновляет cookie в ответе и записывает токен в форму.
+func EnsureCSRFToken(w http.ResponseWriter, r *http.Request, form *Form) (string, error) {
+	token, err := RequestCSRFToken(r)
+	if err != nil {
+		return "", err
+	}
+
+	SetCSRFCookie(w, r, token)
+	form.AddCSRFToken(token)
+	return token, nil
+}
+
+// RequestCSRFToken возвращает CSRF-токен из cookie запроса или генерирует новый, если его нет.
+func RequestCSRFToken(r *http.Request) (string, error) {
+	if cookie, err := r.Cookie(CSRFCookieName); err == nil && cookie.Value != "" {
+		return cookie.Value, nil
+	}
+
+	// Генерируем новый токен, если в запросе его нет
+	return GenerateCSRFToken()
+}
+
+// SetCSRFCookie устанавливает cookie с CSRF-токеном.
+func SetCSRFCookie(w http.ResponseWriter, r *http.Request, token string) {
+	http.SetCookie(w, NewCSRFCookie(r, token))
+}
+
+// NewCSRFCookie создает cookie с CSRF-токеном для ответа на запрос r.
+// Признак Secure задается SetSecureCookies и не зависит от того, пришел ли запрос по TLS.
+func NewCSRFCookie(r *http.Request, token string) *http.Cookie {
+	return &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// CSRFTokenFromRequest извлекает переданный клиентом CSRF-токен:
+// из заголовка X-CSRF-Token, поля csrf_token, поля, названного в csrf_field (шаблоны форм
+// выводят его с именем по стратегии формы, в том числе пользовательской), или поля формы form_id,
+// названного по стратегии имен по умолчанию либо по встроенным стратегиям (<form_id>_csrf_token, <form_id>[csrf_token]).
+func CSRFTokenFromRequest(r *http.Request) string {
+	if token := r.Header.Get(CSRFHeaderName); token != "" {
+		return token
+	}
+	if token := r.FormValue(CSRFFieldName); token != "" {
+		return token
+	}
+	if name := r.FormValue(CSRFNameField); name != "" {
+		if token := r.FormValue(name); token != "" {
+			return token
+		}
+	}
+	if formID := r.FormValue(FormIDField); formID != "" {
+		for _, strategy := range []NameStrategy{defaultNameStrategy, PrefixNames("_"), BracketNames} {
+			if token := r.FormValue(strategy(formID, CSRFFieldName)); token != "" {
+				return token
+			}
+		}
+	}
+	return ""
+}
+
+// VerifyCSRFToken проверяет, что переданный в запросе CSRF-токен совпадает с токеном из cookie.
+func VerifyCSRFToken(r *http.Request) error {
+	expectedToken, err := r.Cookie(CSRFCookieName)
+	if err != nil {
+		return ErrCSRFTokenNotFound
+	}
+
+	receivedToken := CSRFTokenFromRequest(r)
+	if receivedToken == "" {
+		return ErrCSRFTokenRequired
+	}
+
+	if subtle.ConstantTimeCompare([]byte(receivedToken), []byte(expectedToken.Value)) != 1 {
+		return ErrInvalidCSRFToken
+	}
+	return nil
+}
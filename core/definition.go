@@ -0,0 +1,282 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidFormDefinition возвращается при построении формы из некорректного описания.
+var ErrInvalidFormDefinition = errors.New("invalid form definition")
+
+// FormDefinition — декларативное описание формы без Go-структуры, например созданное
+// администратором и сохраненное в JSON или YAML:
+//
+//	id: feedback
+//	method: POST
+//	fields:
+//	  - name: email
+//	    type: email
+//	    label: Email
+//	    rules: [required, email]
+//	    message: Please provide a valid email address
+//	  - name: topic
+//	    choices:
+//	      - {value: bug, label: Bug report}
+//	      - {value: idea, label: Idea}
+type FormDefinition struct {
+	ID     string            `json:"id" yaml:"id"`
+	Method string            `json:"method,omitempty" yaml:"method,omitempty"` // По умолчанию POST
+	Fields []FieldDefinition `json:"fields" yaml:"fields"`
+}
+
+// FieldDefinition — описание поля формы, аналог тегов поля структуры.
+type FieldDefinition struct {
+	Name    string   `json:"name" yaml:"name"`
+	Label   string   `json:"label,omitempty" yaml:"label,omitempty"`
+	Type    string   `json:"type,omitempty" yaml:"type,omitempty"` // Тип поля (text, email, number, checkbox...), по умолчанию text
+	Widget  string   `json:"widget,omitempty" yaml:"widget,omitempty"`
+	Rules   []string `json:"rules,omitempty" yaml:"rules,omitempty"`     // Правила, как в теге validate
+	Message string   `json:"message,omitempty" yaml:"message,omitempty"` // Сообщение об ошибке, как в теге validate_msg
+	Choices []Choice `json:"choices,omitempty" yaml:"choices,omitempty"`
+	Pattern string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Default string   `json:"default,omitempty" yaml:"default,omitempty"`
+	Hidden  bool     `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	Source  string   `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// ParseFormDefinitionJSON читает описание формы в формате JSON.
+func ParseFormDefinitionJSON(r io.Reader) (*FormDefinition, error) {
+	var def FormDefinition
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormDefinition, err)
+	}
+	return &def, nil
+}
+
+// ParseFormDefinitionYAML читает описание формы в формате YAML.
+func ParseFormDefinitionYAML(r io.Reader) (*FormDefinition, error) {
+	var def FormDefinition
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormDefinition, err)
+	}
+	return &def, nil
+}
+
+// Form проверяет описание и создает по нему форму. Правила, сообщения, варианты и регулярные
+// выражения хранятся в полях формы, поэтому форма проверяется вызовом Validate(nil)
+// или Validate(form.Values()), а данные после Bind доступны через Values.
+func (d FormDefinition) Form() (*Form, error) {
+	method := d.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	form := &Form{
+		Errs:   make(map[string]string),
+		Method: strings.ToUpper(method),
+		FormID: d.ID,
+	}
+
+	for _, def := range d.Fields {
+		if err := def.validate(); err != nil {
+			return nil, fmt.Errorf("%w: field %q: %v", ErrInvalidFormDefinition, def.Name, err)
+		}
+		for _, field := range form.Fields {
+			if field.Name == def.Name {
+				return nil, fmt.Errorf("%w: duplicate field %q", ErrInvalidFormDefinition, def.Name)
+			}
+		}
+		form.AddField(def.field())
+	}
+	form.recordInitial()
+	return form, nil
+}
+
+// validate проверяет описание поля.
+func (d FieldDefinition) validate() error {
+	if d.Name == "" {
+		return errors.New("name is required")
+	}
+	for _, rule := range d.Rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required", "email":
+		case "min", "max":
+			if _, err := strconv.Atoi(param); err != nil {
+				return fmt.Errorf("rule %q: expected a number", rule)
+			}
+		default:
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+	}
+	if d.Pattern != "" {
+		if _, err := regexp.Compile(anchorPattern(d.Pattern)); err != nil {
+			return fmt.Errorf("pattern: %v", err)
+		}
+	}
+	if source, _, _ := strings.Cut(d.Source, ":"); source != "" {
+		switch source {
+		case SourceForm, SourceQuery, SourcePath, SourceHeader, SourceCookie:
+		default:
+			return fmt.Errorf("unknown source %q", source)
+		}
+	}
+	return nil
+}
+
+// field создает поле формы по описанию.
+func (d FieldDefinition) field() *Field {
+	fieldType := d.Type
+	if fieldType == "" {
+		fieldType = "text"
+	}
+	field := NewField(d.Name, fieldType)
+	if d.Label != "" {
+		field.Label = d.Label
+	}
+	field.Value = d.Default
+	field.Hidden = d.Hidden
+	field.Rules = d.Rules
+	field.Message = d.Message
+	field.Choices = d.Choices
+	field.Pattern = d.Pattern
+	field.Source = d.Source
+	field.WidgetName = d.Widget
+	if len(d.Choices) > 0 && d.Widget == "" {
+		field.WidgetName = "select" // Поле с вариантами по умолчанию выводится списком, как и в структурах
+	}
+	return field
+}
+
+// Values возвращает значения полей формы по именам для форм без Go-структуры.
+// Поля типа checkbox становятся bool, number — int64 или float64 (пустое значение — nil),
+// вложенные значения из JSON-тела сохраняются как есть, остальные — строки.
+func (f *Form) Values() map[string]any {
+	values := make(map[string]any, len(f.Fields))
+	for _, field := range f.Fields {
+		if field.Name == "" {
+			continue
+		}
+		values[field.Name] = typedValue(field)
+	}
+	return values
+}
+
+// typedValue приводит значение поля к типу, соответствующему типу поля.
+func typedValue(field *Field) any {
+	switch value := field.Value.(type) {
+	case []interface{}, map[string]interface{}:
+		return value
+	}
+
+	value := valueToString(field.Value)
+	switch field.Type {
+	case "checkbox":
+		return comparableValue(field) != ""
+	case "number":
+		if value == "" {
+			return nil
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	}
+	return value
+}
+
+// FieldOption настраивает описание поля в FormBuilder.
+type FieldOption func(def *FieldDefinition)
+
+// WithLabel задает подпись поля.
+func WithLabel(label string) FieldOption {
+	return func(def *FieldDefinition) { def.Label = label }
+}
+
+// WithRules задает правила валидации, как в теге validate.
+func WithRules(rules ...string) FieldOption {
+	return func(def *FieldDefinition) { def.Rules = append(def.Rules, rules...) }
+}
+
+// WithMessage задает сообщение об ошибке валидации, как в теге validate_msg.
+func WithMessage(message string) FieldOption {
+	return func(def *FieldDefinition) { def.Message = message }
+}
+
+// WithChoices задает варианты выбора.
+func WithChoices(choices ...Choice) FieldOption {
+	return func(def *FieldDefinition) { def.Choices = append(def.Choices, choices...) }
+}
+
+// WithPattern задает регулярное выражение, которому должно соответствовать значение.
+func WithPattern(pattern string) FieldOption {
+	return func(def *FieldDefinition) { def.Pattern = pattern }
+}
+
+// WithDefault задает начальное значение поля.
+func WithDefault(value string) FieldOption {
+	return func(def *FieldDefinition) { def.Default = value }
+}
+
+// WithWidget задает имя виджета поля.
+func WithWidget(name string) FieldOption {
+	return func(def *FieldDefinition) { def.Widget = name }
+}
+
+// WithSource задает источник значения поля, как в теге source.
+func WithSource(source string) FieldOption {
+	return func(def *FieldDefinition) { def.Source = source }
+}
+
+// Hidden делает поле скрытым: оно привязывается, но не выводится.
+func Hidden() FieldOption {
+	return func(def *FieldDefinition) { def.Hidden = true }
+}
+
+// FormBuilder строит описание формы в коде:
+//
+//	form, err := core.NewFormBuilder("feedback", http.MethodPost).
+//		Field("email", "email", core.WithLabel("Email"), core.WithRules("required", "email")).
+//		Field("message", "text", core.WithWidget("textarea"), core.WithRules("required", "max=500")).
+//		Build()
+type FormBuilder struct {
+	def FormDefinition
+}
+
+// NewFormBuilder создает построитель формы с идентификатором formID и методом method.
+func NewFormBuilder(formID, method string) *FormBuilder {
+	return &FormBuilder{def: FormDefinition{ID: formID, Method: method}}
+}
+
+// Field добавляет поле с именем name и типом fieldType.
+func (b *FormBuilder) Field(name, fieldType string, options ...FieldOption) *FormBuilder {
+	def := FieldDefinition{Name: name, Type: fieldType}
+	for _, option := range options {
+		option(&def)
+	}
+	b.def.Fields = append(b.def.Fields, def)
+	return b
+}
+
+// Definition возвращает построенное описание формы, например для сохранения в JSON или YAML.
+func (b *FormBuilder) Definition() FormDefinition {
+	return b.def
+}
+
+// Build проверяет описание и создает форму.
+func (b *FormBuilder) Build() (*Form, error) {
+	return b.def.Form()
+}
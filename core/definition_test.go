@@ -0,0 +1,190 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const feedbackDefinitionYAML = `
+id: feedback
+fields:
+  - name: email
+    type: email
+    label: Email
+    rules: [required, email]
+    message: Please provide a valid email address
+  - name: topic
+    choices:
+      - {value: bug, label: Bug report}
+      - {value: idea, label: Idea}
+    default: idea
+  - name: rating
+    type: number
+    rules: [required]
+    message: Rating is required
+  - name: subscribe
+    type: checkbox
+`
+
+func TestFormDefinitionYAML(t *testing.T) {
+	def, err := ParseFormDefinitionYAML(strings.NewReader(feedbackDefinitionYAML))
+	if err != nil {
+		t.Fatalf("ParseFormDefinitionYAML failed: %v", err)
+	}
+	form, err := def.Form()
+	if err != nil {
+		t.Fatalf("Form failed: %v", err)
+	}
+
+	html, err := form.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	for _, want := range []string{`<form id="feedback" method="POST">`, `<select id="feedback_topic"`, `<option value="idea" selected>Idea</option>`} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("Expected HTML to contain %q:\n%s", want, html)
+		}
+	}
+
+	data := url.Values{"feedback_email": {"john@example.com"}, "feedback_topic": {"bug"}, "feedback_rating": {"5"}, "feedback_subscribe": {"on"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := form.Bind(req); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	values := form.Values()
+	if err := form.Validate(values); err != nil {
+		t.Fatalf("Validate failed: %v", form.Errs)
+	}
+
+	want := map[string]any{"email": "john@example.com", "topic": "bug", "rating": int64(5), "subscribe": true}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("Expected %v, got %v", want, values)
+	}
+}
+
+func TestFormDefinitionValidation(t *testing.T) {
+	form, err := NewFormBuilder("feedback", http.MethodPost).
+		Field("email", "email", WithRules("required", "email"), WithMessage("Please provide a valid email address")).
+		Field("name", "text", WithRules("min=3"), WithMessage("Name must be at least %d characters")).
+		Field("code", "text", WithPattern(`[A-Z]{3}`), WithMessage("Invalid code")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email": "john", "name": "Jo", "code": "abc"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if err := form.Bind(req); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if err := form.Validate(nil); err == nil {
+		t.Fatal("Expected validation errors")
+	}
+
+	want := map[string]string{
+		"email": "Please provide a valid email address",
+		"name":  "Name must be at least 3 characters",
+		"code":  "Invalid code",
+	}
+	if !reflect.DeepEqual(form.Errs, want) {
+		t.Errorf("Expected %v, got %v", want, form.Errs)
+	}
+}
+
+func TestFormBuilderDefinitionJSON(t *testing.T) {
+	builder := NewFormBuilder("survey", http.MethodPost).
+		Field("color", "text", WithLabel("Color"), WithChoices(Choice{Value: "red", Label: "Red"}), WithDefault("red")).
+		Field("token", "text", Hidden(), WithSource(SourceHeader+":X-Token"))
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(builder.Definition()); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	def, err := ParseFormDefinitionJSON(&buf)
+	if err != nil {
+		t.Fatalf("ParseFormDefinitionJSON failed: %v", err)
+	}
+	if !reflect.DeepEqual(*def, builder.Definition()) {
+		t.Errorf("Expected %+v, got %+v", builder.Definition(), *def)
+	}
+
+	form, err := def.Form()
+	if err != nil {
+		t.Fatalf("Form failed: %v", err)
+	}
+	if field := form.Fields[0]; field.WidgetName != "select" || field.Value != "red" || field.Label != "Color" {
+		t.Errorf("Unexpected field: %+v", field)
+	}
+	if !form.Fields[1].Hidden {
+		t.Error("Expected the token field to be hidden")
+	}
+}
+
+func TestFormDefinitionCustomWidget(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"default.html":       `{{ range .Fields }}{{ .Widget }}{{ end }}`,
+		"widget_slider.html": `<input type="range" name="{{ .HTMLName }}" value="{{ .Value }}">`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	renderer, err := NewTemplateRenderer(dir, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	// Виджет из шаблона widget_<имя>.html не зарегистрирован, но описание его принимает
+	def, err := ParseFormDefinitionJSON(strings.NewReader(`{"id": "f", "fields": [{"name": "volume", "widget": "slider"}]}`))
+	if err != nil {
+		t.Fatalf("ParseFormDefinitionJSON failed: %v", err)
+	}
+	form, err := def.Form()
+	if err != nil {
+		t.Fatalf("Form failed: %v", err)
+	}
+	form.RenderHTML = true
+	form.Fields[0].Value = "7"
+
+	rec := httptest.NewRecorder()
+	if err := renderer.RenderForm(rec, httptest.NewRequest(http.MethodGet, "/", nil), form); err != nil {
+		t.Fatalf("RenderForm failed: %v", err)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `<input type="range" name="f_volume" value="7">`) {
+		t.Errorf("Expected overridden slider widget, got:\n%s", body)
+	}
+}
+
+func TestFormDefinitionErrors(t *testing.T) {
+	definitions := []string{
+		`{"id": "f", "fields": [{"type": "text"}]}`,
+		`{"id": "f", "fields": [{"name": "a"}, {"name": "a"}]}`,
+		`{"id": "f", "fields": [{"name": "a", "rules": ["unique"]}]}`,
+		`{"id": "f", "fields": [{"name": "a", "rules": ["min=x"]}]}`,
+		`{"id": "f", "fields": [{"name": "a", "pattern": "("}]}`,
+		`{"id": "f", "fields": [{"name": "a", "source": "session"}]}`,
+		`{"id": "f", "fields": [{"name": "a", "validate": "required"}]}`,
+	}
+
+	for _, data := range definitions {
+		def, err := ParseFormDefinitionJSON(strings.NewReader(data))
+		if err == nil {
+			_, err = def.Form()
+		}
+		if !errors.Is(err, ErrInvalidFormDefinition) {
+			t.Errorf("%s: expected ErrInvalidFormDefinition, got %v", data, err)
+		}
+	}
+}
@@ -1,19 +1,86 @@
 package core
 
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
 // Field представляет поля формы.
 type Field struct {
 	Name             string         // Имя поля
+	Label            string         // Подпись поля (по умолчанию совпадает с именем)
 	Type             string         // Тип поля (text, email, password и т.д.)
 	Value            interface{}    // Значение поля
 	Error            string         // Ошибка валидации
 	Hidden           bool           // Скрытое поле
 	CustomValidation ValidationFunc // Кастомная функция валидации
+	Rules            []string       // Правила валидации из тега validate
+	Message          string         // Сообщение об ошибке валидации из тега validate_msg
+	WidgetName       string         // Имя виджета из тега widget
+	Widget           Widget         // Виджет поля, заданный через Form.SetWidget
+	Choices          []Choice       // Варианты выбора из тега choices
+	Pattern          string         // Регулярное выражение из тега pattern
+	Source           string         // Источник значения из тега source (query, path, header, cookie, form)
+	Default          string         // Значение по умолчанию из тега default для новых записей
+
+	kind reflect.Kind // Тип поля модели, если поле создано из структуры
 }
 
 // NewField создает новое поле.
 func NewField(name, fieldType string) *Field {
 	return &Field{
-		Name: name,
-		Type: fieldType,
+		Name:  name,
+		Label: name,
+		Type:  fieldType,
+	}
+}
+
+// NewModelField создает поле для поля модели с типом kind, как при разборе структуры в NewForm.
+func NewModelField(name string, kind reflect.Kind) *Field {
+	field := NewField(name, getFieldType(kind))
+	field.kind = kind
+	return field
+}
+
+// StringValue возвращает значение поля строкой; вложенные значения из JSON-тела кодируются в JSON.
+func (f *Field) StringValue() string {
+	return valueToString(f.Value)
+}
+
+// HasRule проверяет, есть ли у поля правило с указанным именем (например, required или min).
+func (f *Field) HasRule(name string) bool {
+	_, ok := f.RuleParam(name)
+	return ok
+}
+
+// RuleParam возвращает параметр правила, например "3" для min=3.
+func (f *Field) RuleParam(name string) (string, bool) {
+	for _, rule := range f.Rules {
+		ruleName, param, _ := strings.Cut(rule, "=")
+		if ruleName == name {
+			return param, true
+		}
+	}
+	return "", false
+}
+
+// valueToString приводит значение поля к строке, не паникуя на нестроковых типах.
+func valueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}, map[string]interface{}:
+		// Вложенные значения из JSON-тела; пустая коллекция равносильна пустому полю
+		if reflect.ValueOf(v).Len() == 0 {
+			return ""
+		}
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
 	}
 }
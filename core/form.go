@@ -1,18 +1,29 @@
 package core
 
 import (
+	"encoding/json"
+	"fmt"
+	"html/template"
 	"net/http"
 	"reflect"
+	"strconv"
 )
 
 // Form представляет HTML-форму.
 type Form struct {
-	Fields     []*Field          // Поля формы
-	CSRF       string            // CSRF-токен
-	Errs       map[string]string // Ошибки валидации
-	Method     string            // Метод HTTP (GET, POST и т.д.)
-	FormID     string            // Идентификатор формы
-	RenderHTML bool              // Флаг для рендеринга HTML
+	Fields []*Field          // Поля формы
+	CSRF   string            // CSRF-токен
+	Errs   map[string]string // Ошибки валидации
+	Method string            // Метод HTTP (GET, POST и т.д.)
+	FormID string            // Идентификатор формы
+	// Deprecated: формат ответа выбирает Respond по заголовкам запроса.
+	RenderHTML   bool         // Флаг для рендеринга HTML
+	Theme        *Theme       // Тема встроенного HTML-рендерера (по умолчанию SetDefaultTheme)
+	NameStrategy NameStrategy // Стратегия имен элементов HTML-формы (по умолчанию SetDefaultNameStrategy)
+
+	widgetTemplates *template.Template // Шаблоны пользователя с переопределениями виджетов
+	initial         map[string]string  // Начальные значения полей для Changed
+	submitted       map[string]bool    // Имена полей, переданных в последнем запросе
 }
 
 // FormResponse представляет данные формы для ответа.
@@ -22,26 +33,72 @@ type FormResponse struct {
 	CSRF   string
 	Method string
 	FormID string
+	// CSRFName — имя скрытого поля с CSRF-токеном по стратегии имен формы.
+	CSRFName string
+
+	form *Form // Исходная форма для функций шаблонов (formField, formErrors и т.д.)
 }
 
 // FieldResponse представляет упрощенную версию Field для ответа.
 type FieldResponse struct {
-	Name   string
-	Type   string
-	Value  string
-	Error  string
-	Hidden bool
+	Name      string
+	ID        string // Атрибут id элемента управления
+	InputName string // Атрибут name элемента управления по стратегии имен формы
+	Label     string
+	Type      string
+	Value     string
+	Error     string
+	Hidden    bool
+	Widget    template.HTML `json:"-"` // Элемент управления, отрендеренный виджетом поля
 }
 
 // NewForm создает новую форму на основе модели.
 func NewForm(model interface{}, method, formID string) *Form {
-	fields := parseModel(reflect.ValueOf(model))
-	return &Form{
+	return NewFormFromFields(method, formID, parseModel(reflect.ValueOf(model))...)
+}
+
+// NewFormWithDefaults создает форму новой записи: незаполненные поля модели получают
+// значения из тега default (см. ApplyDefaults).
+func NewFormWithDefaults(model interface{}, method, formID string) *Form {
+	form := NewForm(model, method, formID)
+	form.ApplyDefaults()
+	return form
+}
+
+// NewFormFromFields создает форму из готовых полей; текущие значения полей становятся начальными.
+// Используется кодом, сгенерированным goform gen, вместо разбора модели через рефлексию.
+func NewFormFromFields(method, formID string, fields ...*Field) *Form {
+	form := &Form{
 		Fields: fields,
 		Errs:   make(map[string]string),
 		Method: method,
 		FormID: formID,
 	}
+	form.recordInitial()
+	return form
+}
+
+// FieldByName возвращает поле формы по имени или nil.
+func (f *Form) FieldByName(name string) *Field {
+	for _, field := range f.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// BindModel создает форму для модели, привязывает к ней данные из запроса и обновляет модель.
+// Используется адаптерами фреймворков в middleware привязки.
+func BindModel(r *http.Request, model interface{}, method, formID string) (*Form, error) {
+	form := NewForm(model, method, formID)
+	if err := form.Bind(r); err != nil {
+		return nil, err
+	}
+	if err := UpdateModelFromForm(model, form); err != nil {
+		return nil, err
+	}
+	return form, nil
 }
 
 // AddField добавляет поле в форму.
@@ -49,9 +106,9 @@ func (f *Form) AddField(field *Field) {
 	f.Fields = append(f.Fields, field)
 }
 
-// Bind привязывает данные из запроса к форме.
+// Bind привязывает данные из запроса к форме. Поля, которых нет в запросе, получают пустые значения.
 func (f *Form) Bind(r *http.Request) error {
-	return bindForm(r, f)
+	return bindForm(r, f, false)
 }
 
 // Validate проверяет данные формы.
@@ -103,7 +160,9 @@ func UpdateModelFromForm(model interface{}, form *Form) error {
 				// Обновляем поле модели значением из формы
 				fieldValue := val.Field(i)
 				if fieldValue.CanSet() {
-					fieldValue.SetString(formField.Value.(string))
+					if err := setFieldValue(fieldValue, formField.Value); err != nil {
+						return fmt.Errorf("field %s: %w", tag, err)
+					}
 				}
 				break
 			}
@@ -113,7 +172,73 @@ func UpdateModelFromForm(model interface{}, form *Form) error {
 	return nil
 }
 
+// setFieldValue записывает значение поля формы в поле модели.
+// Вложенные объекты и массивы из JSON-тела запроса переносятся через encoding/json.
+func setFieldValue(fieldValue reflect.Value, value interface{}) error {
+	switch value.(type) {
+	case []interface{}, map[string]interface{}:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, fieldValue.Addr().Interface())
+	}
+	return setModelValue(fieldValue, valueToString(value))
+}
+
+// setModelValue записывает строковое значение из формы в поле модели с учетом его типа.
+// Пустая строка оставляет нулевое значение для нестроковых типов.
+func setModelValue(fieldValue reflect.Value, value string) error {
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value == "" {
+			fieldValue.SetInt(0)
+			return nil
+		}
+		n, err := strconv.ParseInt(value, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value == "" {
+			fieldValue.SetUint(0)
+			return nil
+		}
+		n, err := strconv.ParseUint(value, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if value == "" {
+			fieldValue.SetFloat(0)
+			return nil
+		}
+		n, err := strconv.ParseFloat(value, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetFloat(n)
+	case reflect.Bool:
+		// Чекбокс передает "on", если отмечен, и ничего, если нет
+		fieldValue.SetBool(value == "on" || value == "true" || value == "1")
+	default:
+		// Составные поля заполняются только вложенными значениями из JSON-тела
+		if value == "" {
+			return nil
+		}
+		return fmt.Errorf("unsupported field kind %s", fieldValue.Kind())
+	}
+	return nil
+}
+
 // ToResponse возвращает данные формы в зависимости от флага RenderHTML.
+//
+// Deprecated: используйте Respond, который выбирает формат по заголовкам запроса,
+// или ToHTMLResponse и ToJSON напрямую.
 func (f *Form) ToResponse() interface{} {
 	if f.RenderHTML {
 		return f.ToHTMLResponse()
@@ -125,29 +250,33 @@ func (f *Form) ToResponse() interface{} {
 func (f *Form) ToHTMLResponse() FormResponse {
 	fields := make([]FieldResponse, len(f.Fields))
 	for i, field := range f.Fields {
-		value := ""
-		if field.Value != nil {
-			value = field.Value.(string)
-		}
 		fields[i] = FieldResponse{
-			Name:   field.Name,
-			Type:   field.Type,
-			Value:  value,
-			Error:  field.Error,
-			Hidden: field.Hidden,
+			Name:      field.Name,
+			ID:        f.inputID(field.Name),
+			InputName: f.InputName(field.Name),
+			Label:     field.Label,
+			Type:      field.Type,
+			Value:     valueToString(field.Value),
+			Error:     field.Error,
+			Hidden:    field.Hidden,
+			Widget:    f.renderWidget(field),
 		}
 	}
 
 	return FormResponse{
-		Fields: fields,
-		Errs:   f.Errs,
-		CSRF:   f.CSRF,
-		Method: f.Method,
-		FormID: f.FormID,
+		Fields:   fields,
+		Errs:     f.Errs,
+		CSRF:     f.CSRF,
+		Method:   f.Method,
+		FormID:   f.FormID,
+		CSRFName: f.CSRFInputName(),
+		form:     f,
 	}
 }
 
 // ToJSONResponse возвращает данные формы в формате JSON.
+//
+// Deprecated: используйте ToJSON, который возвращает версионированное представление формы.
 func (f *Form) ToJSONResponse() map[string]interface{} {
 	data := make(map[string]interface{})
 	for _, field := range f.Fields {
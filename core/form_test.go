@@ -1,8 +1,13 @@
 package core
 
 import (
+	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 type TestForm struct {
@@ -151,3 +156,286 @@ func TestFormToJSON(t *testing.T) {
 		t.Errorf("Expected username 'testuser', got '%v'", jsonData["username"].(map[string]interface{})["value"])
 	}
 }
+
+func TestEnsureCSRFToken(t *testing.T) {
+	model := &TestForm{
+		Method: "POST",
+		FormID: "test_form",
+	}
+
+	// Без cookie токен генерируется
+	form := NewForm(model, model.Method, model.FormID)
+	req := httptest.NewRequest("GET", "/", nil)
+	rec := httptest.NewRecorder()
+	token, err := EnsureCSRFToken(rec, req, form)
+	if err != nil {
+		t.Fatalf("EnsureCSRFToken failed: %v", err)
+	}
+	if token == "" || form.CSRF != token {
+		t.Errorf("Expected form CSRF to be set to generated token, got '%s'", form.CSRF)
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != CSRFCookieName || cookies[0].Value != token {
+		t.Errorf("Expected CSRF cookie with token '%s', got %v", token, cookies)
+	}
+
+	// Токен из cookie используется повторно
+	form = NewForm(model, model.Method, model.FormID)
+	req = httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: "existing"})
+	rec = httptest.NewRecorder()
+	token, err = EnsureCSRFToken(rec, req, form)
+	if err != nil {
+		t.Fatalf("EnsureCSRFToken failed: %v", err)
+	}
+	if token != "existing" || form.CSRF != "existing" {
+		t.Errorf("Expected existing token to be reused, got '%s'", token)
+	}
+}
+
+func TestNewCSRFCookieSecure(t *testing.T) {
+	// Запрос без TLS, как за прокси, завершающим HTTPS
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if cookie := NewCSRFCookie(req, "token123"); !cookie.Secure || !cookie.HttpOnly || cookie.SameSite != http.SameSiteLaxMode {
+		t.Errorf("Expected a Secure, HttpOnly, SameSite=Lax cookie, got %+v", cookie)
+	}
+
+	SetSecureCookies(false)
+	defer SetSecureCookies(true)
+	if cookie := NewCSRFCookie(req, "token123"); cookie.Secure {
+		t.Error("Expected Secure to be disabled by SetSecureCookies(false)")
+	}
+}
+
+func TestTemplateRendererRenderForm(t *testing.T) {
+	renderer, err := NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	model := &TestForm{
+		Method: "POST",
+		FormID: "test_form",
+	}
+	form := NewForm(model, model.Method, model.FormID)
+	form.RenderHTML = true
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: "token123"})
+	rec := httptest.NewRecorder()
+	if err := renderer.RenderForm(rec, req, form); err != nil {
+		t.Fatalf("RenderForm failed: %v", err)
+	}
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `<meta name="csrf-token" content="token123">`) {
+		t.Error("Expected CSRF meta tag in rendered form")
+	}
+	if !strings.Contains(body, `name="test_form_csrf_token" value="token123"`) {
+		t.Error("Expected CSRF hidden field in rendered form")
+	}
+	if len(rec.Result().Cookies()) != 1 {
+		t.Error("Expected CSRF cookie to be set")
+	}
+}
+
+type TypedTestForm struct {
+	Username string  `form:"username" validate:"required,min=3" validate_msg:"Username must be at least 3 characters"`
+	Age      int     `form:"age"`
+	Rating   float64 `form:"rating"`
+	Agree    bool    `form:"agree"`
+	Internal string  `form:"-"`
+	Token    string
+}
+
+func TestTypedFormBind(t *testing.T) {
+	form := NewTypedForm[TypedTestForm]("POST", "typed")
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Form = map[string][]string{
+		"typed_username": {"testuser"},
+		"typed_age":      {"42"},
+		"typed_rating":   {"4.5"},
+		"typed_agree":    {"on"},
+	}
+
+	model, err := form.Bind(req)
+	if err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+
+	expected := TypedTestForm{Username: "testuser", Age: 42, Rating: 4.5, Agree: true}
+	if model != expected {
+		t.Errorf("Expected model %+v, got %+v", expected, model)
+	}
+	if form.Model() != expected {
+		t.Errorf("Expected Model() %+v, got %+v", expected, form.Model())
+	}
+	if err := form.Validate(); err != nil {
+		t.Errorf("Expected no validation errors, got %v", err)
+	}
+}
+
+func TestTypedFormBindInvalidNumber(t *testing.T) {
+	form := NewTypedForm[TypedTestForm]("POST", "typed")
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Form = map[string][]string{
+		"typed_username": {"testuser"},
+		"typed_age":      {"forty"},
+	}
+
+	if _, err := form.Bind(req); err == nil {
+		t.Error("Expected error for non-numeric age, got nil")
+	}
+}
+
+func TestFieldOf(t *testing.T) {
+	form := NewTypedForm[TypedTestForm]("POST", "typed")
+	FieldOf(form, func(m *TypedTestForm) *string { return &m.Username }).Value = "ab"
+
+	if err := form.Validate(); err == nil {
+		t.Error("Expected validation error, got nil")
+	}
+
+	field := FieldOf(form, func(m *TypedTestForm) *string { return &m.Username })
+	if field == nil || field.Name != "username" {
+		t.Fatalf("Expected username field, got %v", field)
+	}
+	if field.Error != "Username must be at least 3 characters" {
+		t.Errorf("Unexpected error message '%s'", field.Error)
+	}
+
+	if FieldOf(form, func(m *TypedTestForm) *string { return &m.Internal }) != nil {
+		t.Error("Expected nil for field excluded from form")
+	}
+	if FieldOf(form, func(m *TypedTestForm) *string { return &m.Token }) != nil {
+		t.Error("Expected nil for field without form tag")
+	}
+	if FieldOf(form, func(m *TypedTestForm) *string { return nil }) != nil {
+		t.Error("Expected nil for nil selector result")
+	}
+}
+
+func TestValueOf(t *testing.T) {
+	form := NewTypedForm[TypedTestForm]("POST", "typed")
+	FieldOf(form, func(m *TypedTestForm) *int { return &m.Age }).Value = "42"
+	FieldOf(form, func(m *TypedTestForm) *bool { return &m.Agree }).Value = "on"
+
+	age, err := ValueOf(form, func(m *TypedTestForm) *int { return &m.Age })
+	if err != nil || age != 42 {
+		t.Errorf("Expected age 42, got %d (%v)", age, err)
+	}
+	agree, err := ValueOf(form, func(m *TypedTestForm) *bool { return &m.Agree })
+	if err != nil || !agree {
+		t.Errorf("Expected agree true, got %v (%v)", agree, err)
+	}
+
+	FieldOf(form, func(m *TypedTestForm) *float64 { return &m.Rating }).Value = "high"
+	if _, err := ValueOf(form, func(m *TypedTestForm) *float64 { return &m.Rating }); err == nil {
+		t.Error("Expected error for non-numeric rating")
+	}
+	if _, err := ValueOf(form, func(m *TypedTestForm) *string { return &m.Internal }); err == nil {
+		t.Error("Expected error for field excluded from form")
+	}
+}
+
+func TestFormNonStringValues(t *testing.T) {
+	form := NewForm(&TypedTestForm{}, "POST", "typed")
+	form.Fields[0].Value = 12345
+	form.Fields[1].Value = nil
+
+	response := form.ToHTMLResponse()
+	if response.Fields[0].Value != "12345" || response.Fields[1].Value != "" {
+		t.Errorf("Unexpected field values %q, %q", response.Fields[0].Value, response.Fields[1].Value)
+	}
+	if err := form.Validate(&TypedTestForm{}); err != nil {
+		t.Errorf("Expected no validation errors, got %v", err)
+	}
+}
+
+func TestFormWriteHTML(t *testing.T) {
+	model := &TestForm{
+		Method: "PUT",
+		FormID: "test_form",
+	}
+	form := NewForm(model, model.Method, model.FormID)
+	form.Fields[0].Value = `"><script>`
+	form.Fields[0].Error = "Username must be at least 3 characters"
+
+	var buf strings.Builder
+	if err := form.WriteHTML(&buf); err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+	html := buf.String()
+
+	expected := []string{
+		`<form id="test_form" method="POST">`,
+		`<input type="hidden" name="_method" value="PUT">`,
+		`<label for="test_form_username">username</label>`,
+		`<input id="test_form_username" type="text" name="test_form_username" value="&#34;&gt;&lt;script&gt;" required minlength="3" aria-invalid="true" aria-describedby="test_form_username_error">`,
+		`<span id="test_form_username_error" class="error" role="alert">Username must be at least 3 characters</span>`,
+		`<input id="test_form_email" type="text" name="test_form_email" value="" required>`,
+		`<input type="hidden" name="test_form_csrf_token" value="">`,
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(html, fragment) {
+			t.Errorf("Expected HTML to contain %s, got:\n%s", fragment, html)
+		}
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("Expected field value to be escaped")
+	}
+
+	rendered, err := form.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	if string(rendered) != html {
+		t.Error("Expected HTML and WriteHTML to produce the same markup")
+	}
+}
+
+func TestFieldLabelAndRules(t *testing.T) {
+	type LabeledForm struct {
+		Name string `form:"name" label:"Full name" validate:"required,max=20"`
+	}
+	form := NewForm(&LabeledForm{}, "POST", "labeled")
+
+	field := form.Fields[0]
+	if field.Label != "Full name" {
+		t.Errorf("Expected label 'Full name', got '%s'", field.Label)
+	}
+	if !field.HasRule("required") || field.HasRule("email") {
+		t.Errorf("Unexpected rules %v", field.Rules)
+	}
+	if param, ok := field.RuleParam("max"); !ok || param != "20" {
+		t.Errorf("Expected max=20, got '%s'", param)
+	}
+}
+
+func TestLayeredFSTemplateRenderer(t *testing.T) {
+	overrides := fstest.MapFS{
+		"default.html": {Data: []byte(`override {{ .FormID }}`)},
+	}
+	defaults := fstest.MapFS{
+		"default.html": {Data: []byte(`default {{ .FormID }}`)},
+		"other.html":   {Data: []byte(`other {{ .FormID }}`)},
+	}
+
+	renderer, err := NewTemplateRendererFS(LayeredFS(os.DirFS("missing_dir"), overrides, defaults), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	data := FormResponse{FormID: "test_form"}
+	for name, expected := range map[string]string{"": "override test_form", "other.html": "other test_form"} {
+		var buf strings.Builder
+		if err := renderer.Execute(&buf, name, data); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if buf.String() != expected {
+			t.Errorf("Expected '%s', got '%s'", expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	FormIDField  = "form_id"   // Скрытое поле с идентификатором отправленной формы
+	FormIDHeader = "X-Form-ID" // Заголовок с идентификатором формы для AJAX-запросов с JSON-телом
+)
+
+var (
+	ErrUnknownForm = errors.New("unknown form")               // Запрос отправлен формой, которой нет в наборе
+	ErrFormInvalid = errors.New("form has validation errors") // Данные отправленной формы не прошли проверку
+)
+
+// FormSet — набор форм одной страницы. Dispatch определяет по полю form_id, какая форма
+// отправлена, и привязывает данные только к ней; остальные формы не меняются.
+type FormSet struct {
+	forms     []*Form
+	models    map[string]interface{}
+	submitted *Form
+}
+
+// NewFormSet создает пустой набор форм.
+func NewFormSet() *FormSet {
+	return &FormSet{models: make(map[string]interface{})}
+}
+
+// Add добавляет в набор форму и ее модель (указатель на структуру).
+// Для форм без структуры (FormBuilder, FormDefinition) модель равна nil, а данные читаются через Form.Values.
+// Форма с тем же FormID заменяется.
+func (s *FormSet) Add(form *Form, model interface{}) *FormSet {
+	for i, existing := range s.forms {
+		if existing.FormID == form.FormID {
+			s.forms[i] = form
+			s.models[form.FormID] = model
+			return s
+		}
+	}
+	s.forms = append(s.forms, form)
+	s.models[form.FormID] = model
+	return s
+}
+
+// Form возвращает форму по идентификатору или nil.
+func (s *FormSet) Form(formID string) *Form {
+	for _, form := range s.forms {
+		if form.FormID == formID {
+			return form
+		}
+	}
+	return nil
+}
+
+// Forms возвращает формы набора в порядке добавления.
+func (s *FormSet) Forms() []*Form {
+	return s.forms
+}
+
+// Submitted возвращает форму, обработанную Dispatch, или nil.
+func (s *FormSet) Submitted() *Form {
+	return s.submitted
+}
+
+// SubmittedID возвращает идентификатор отправленной формы из поля form_id или заголовка X-Form-ID.
+func SubmittedID(r *http.Request) string {
+	if formID := r.Header.Get(FormIDHeader); formID != "" {
+		return formID
+	}
+	return r.FormValue(FormIDField)
+}
+
+// Dispatch находит отправленную форму, привязывает к ней данные запроса, обновляет ее модель,
+// если это указатель на структуру, и проверяет данные. Ошибки валидации возвращаются как ErrFormInvalid и остаются в форме;
+// если формы с таким идентификатором нет, возвращается ErrUnknownForm.
+func (s *FormSet) Dispatch(r *http.Request) (*Form, error) {
+	formID := SubmittedID(r)
+	form := s.Form(formID)
+	if form == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownForm, formID)
+	}
+	s.submitted = form
+
+	model := s.models[formID]
+	if err := form.Bind(r); err != nil {
+		return form, err
+	}
+	if isStructPointer(model) {
+		if err := UpdateModelFromForm(model, form); err != nil {
+			return form, err
+		}
+	}
+	if err := form.Validate(model); err != nil {
+		return form, fmt.Errorf("%w: %v", ErrFormInvalid, err)
+	}
+	return form, nil
+}
+
+// EnsureCSRFToken получает CSRF-токен из cookie запроса или генерирует новый, обновляет cookie
+// и записывает токен во все формы набора.
+func (s *FormSet) EnsureCSRFToken(w http.ResponseWriter, r *http.Request) (string, error) {
+	token, err := RequestCSRFToken(r)
+	if err != nil {
+		return "", err
+	}
+	SetCSRFCookie(w, r, token)
+	for _, form := range s.forms {
+		form.AddCSRFToken(token)
+	}
+	return token, nil
+}
+
+// Status возвращает статус ответа для страницы: 422, если в отправленной форме есть ошибки, иначе 200.
+func (s *FormSet) Status() int {
+	if s.submitted == nil {
+		return http.StatusOK
+	}
+	return ResponseStatus(s.submitted)
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+type LoginForm struct {
+	Email    string `form:"email" validate:"required,email" validate_msg:"Invalid email"`
+	Password string `form:"password" validate:"required" validate_msg:"Password is required"`
+}
+
+type NewsletterForm struct {
+	Email string `form:"email" validate:"required,email" validate_msg:"Invalid email"`
+}
+
+func newPageFormSet() (*FormSet, *LoginForm, *NewsletterForm) {
+	login, newsletter := &LoginForm{}, &NewsletterForm{}
+	set := NewFormSet().
+		Add(NewForm(login, http.MethodPost, "login"), login).
+		Add(NewForm(newsletter, http.MethodPost, "newsletter"), newsletter)
+	return set, login, newsletter
+}
+
+func newFormSetRequest(data url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestFormSetDispatch(t *testing.T) {
+	set, login, newsletter := newPageFormSet()
+
+	form, err := set.Dispatch(newFormSetRequest(url.Values{
+		FormIDField:        {"newsletter"},
+		"newsletter_email": {"john@example.com"},
+		"login_email":      {"other@example.com"},
+	}))
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if form != set.Form("newsletter") || set.Submitted() != form {
+		t.Error("Expected the newsletter form to be dispatched")
+	}
+	if newsletter.Email != "john@example.com" {
+		t.Errorf("Expected 'john@example.com', got '%s'", newsletter.Email)
+	}
+	if login.Email != "" || set.Form("login").Fields[0].Value != "" {
+		t.Error("Expected the login form to stay untouched")
+	}
+}
+
+func TestFormSetDispatchValidation(t *testing.T) {
+	set, _, _ := newPageFormSet()
+
+	form, err := set.Dispatch(newFormSetRequest(url.Values{FormIDField: {"login"}, "login_email": {"john"}}))
+	if !errors.Is(err, ErrFormInvalid) {
+		t.Fatalf("Expected ErrFormInvalid, got %v", err)
+	}
+	if form.Errs["password"] != "Password is required" || set.Status() != http.StatusUnprocessableEntity {
+		t.Errorf("Unexpected errors: %v", form.Errs)
+	}
+	if len(set.Form("newsletter").Errs) != 0 {
+		t.Error("Expected no errors in the newsletter form")
+	}
+
+	if _, err := set.Dispatch(newFormSetRequest(url.Values{FormIDField: {"search"}})); !errors.Is(err, ErrUnknownForm) {
+		t.Errorf("Expected ErrUnknownForm, got %v", err)
+	}
+}
+
+func TestFormSetDispatchDynamicForm(t *testing.T) {
+	feedback, err := NewFormBuilder("feedback", http.MethodPost).
+		Field("message", "text", WithRules("required"), WithMessage("Message is required")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	set, _, _ := newPageFormSet()
+	set.Add(feedback, nil)
+
+	form, err := set.Dispatch(newFormSetRequest(url.Values{FormIDField: {"feedback"}, "feedback_message": {"Hello"}}))
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if form != feedback || form.Values()["message"] != "Hello" {
+		t.Errorf("Expected the feedback form with message 'Hello', got %v", form.Values())
+	}
+
+	_, err = set.Dispatch(newFormSetRequest(url.Values{FormIDField: {"feedback"}}))
+	if !errors.Is(err, ErrFormInvalid) || feedback.Errs["message"] != "Message is required" {
+		t.Errorf("Expected ErrFormInvalid with message error, got %v (%v)", err, feedback.Errs)
+	}
+}
+
+func TestRenderFormSet(t *testing.T) {
+	fsys := fstest.MapFS{
+		"page.html": {Data: []byte(`{{ formStart (.Form "login") }}{{ csrfField (.Form "login") }}{{ formEnd }}` +
+			`{{ formErrors (.Form "newsletter") }}{{ csrfField (.Form "newsletter") }}`)},
+	}
+	renderer, err := NewTemplateRendererFS(fsys, "page.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	set, _, _ := newPageFormSet()
+	req := newFormSetRequest(url.Values{FormIDField: {"newsletter"}})
+	req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: "token123"})
+	set.Dispatch(req)
+
+	rec := httptest.NewRecorder()
+	if err := renderer.RenderFormSet(rec, req, "page.html", set); err != nil {
+		t.Fatalf("RenderFormSet failed: %v", err)
+	}
+	body := rec.Body.String()
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", rec.Code)
+	}
+	for _, want := range []string{
+		`<form id="login" method="POST">`,
+		`name="login_csrf_token" value="token123"`,
+		`name="newsletter_csrf_token" value="token123"`,
+		`<li>Invalid email</li>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected page to contain %q:\n%s", want, body)
+		}
+	}
+	if got := len(rec.Result().Cookies()); got != 1 {
+		t.Errorf("Expected a single CSRF cookie, got %d", got)
+	}
+}
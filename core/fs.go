@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+	"io/fs"
+	"sort"
+)
+
+// layeredFS объединяет несколько файловых систем: файл берется из первого слоя, в котором он есть.
+type layeredFS []fs.FS
+
+// LayeredFS возвращает файловую систему, в которой файлы из первых слоев перекрывают файлы
+// из последующих. Используется для переопределения встроенных шаблонов файлами пользователя:
+//
+//	LayeredFS(os.DirFS("templates"), goform.Templates())
+//
+// Отсутствующие слои (например, несуществующая директория) пропускаются.
+func LayeredFS(layers ...fs.FS) fs.FS {
+	return layeredFS(layers)
+}
+
+// Open открывает файл из первого слоя, в котором он существует.
+func (l layeredFS) Open(name string) (fs.File, error) {
+	for _, layer := range l {
+		file, err := layer.Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir объединяет содержимое директории из всех слоев.
+func (l layeredFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	found := false
+
+	for _, layer := range l {
+		layerEntries, err := fs.ReadDir(layer, name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		found = true
+
+		for _, entry := range layerEntries {
+			if !seen[entry.Name()] {
+				seen[entry.Name()] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+)
+
+// formRef реализуется значениями, по которым функции шаблонов находят форму:
+// *Form (а значит и *TypedForm) и FormResponse, полученный из Form.ToHTMLResponse.
+type formRef interface {
+	formRef() *Form
+}
+
+func (f *Form) formRef() *Form { return f }
+
+func (r FormResponse) formRef() *Form { return r.form }
+
+// FuncMap возвращает функции шаблонов для вывода частей формы в произвольных шаблонах.
+// Функции подключаются к шаблонам TemplateRenderer автоматически:
+//
+//	{{ formStart .Form }}
+//	{{ formErrors .Form }}
+//	{{ formField .Form "Email" }}
+//	{{ csrfField .Form }}
+//	{{ formSubmit .Form }}
+//	{{ formEnd }}
+//
+// Аргументом может быть *Form, *TypedForm или FormResponse, полученный из Form.ToHTMLResponse.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"formStart":  formStart,
+		"formEnd":    formEnd,
+		"formField":  formField,
+		"formFields": formFields,
+		"formErrors": formErrors,
+		"csrfField":  csrfField,
+		"formSubmit": formSubmit,
+	}
+}
+
+// formStart выводит открывающий тег form со скрытыми полями _method и form_id.
+func formStart(v any) (template.HTML, error) {
+	form, err := formOf(v)
+	if err != nil {
+		return "", err
+	}
+	return executeFormPart("goform_start", form.htmlData())
+}
+
+// formEnd выводит закрывающий тег form.
+func formEnd() (template.HTML, error) {
+	return executeFormPart("goform_end", nil)
+}
+
+// formField выводит поле формы с подписью, элементом управления и текстом ошибки.
+func formField(v any, name string) (template.HTML, error) {
+	form, err := formOf(v)
+	if err != nil {
+		return "", err
+	}
+	for _, field := range form.Fields {
+		if field.Name == name {
+			return executeFormPart("goform_field", form.htmlField(field))
+		}
+	}
+	return "", fmt.Errorf("formField: field %q not found in form %q", name, form.FormID)
+}
+
+// formFields выводит все видимые поля формы.
+func formFields(v any) (template.HTML, error) {
+	form, err := formOf(v)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	for _, field := range form.Fields {
+		if field.Hidden {
+			continue
+		}
+		html, err := executeFormPart("goform_field", form.htmlField(field))
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(string(html))
+		buf.WriteString("\n")
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// formErrors выводит список ошибок формы: сначала ошибки полей в порядке полей,
+// затем остальные ошибки в порядке ключей.
+func formErrors(v any) (template.HTML, error) {
+	form, err := formOf(v)
+	if err != nil {
+		return "", err
+	}
+
+	var errs []string
+	seen := make(map[string]bool)
+	for _, field := range form.Fields {
+		if msg, ok := form.Errs[field.Name]; ok {
+			errs = append(errs, msg)
+			seen[field.Name] = true
+		}
+	}
+	var keys []string
+	for key := range form.Errs {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		errs = append(errs, form.Errs[key])
+	}
+
+	return executeFormPart("goform_errors", struct {
+		Theme  *Theme
+		Errors []string
+	}{form.theme(), errs})
+}
+
+// csrfField выводит скрытое поле с CSRF-токеном формы.
+func csrfField(v any) (template.HTML, error) {
+	form, err := formOf(v)
+	if err != nil {
+		return "", err
+	}
+	return executeFormPart("goform_csrf", form.htmlData())
+}
+
+// formSubmit выводит кнопку отправки формы.
+func formSubmit(v any) (template.HTML, error) {
+	form, err := formOf(v)
+	if err != nil {
+		return "", err
+	}
+	return executeFormPart("goform_submit", form.htmlData())
+}
+
+// formOf возвращает форму, переданную функции шаблона.
+func formOf(v any) (*Form, error) {
+	if ref, ok := v.(formRef); ok {
+		if form := ref.formRef(); form != nil {
+			return form, nil
+		}
+	}
+	return nil, fmt.Errorf("expected form, got %T", v)
+}
+
+// executeFormPart выполняет часть встроенного шаблона формы.
+func executeFormPart(name string, data any) (template.HTML, error) {
+	var buf bytes.Buffer
+	if err := formTemplate.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
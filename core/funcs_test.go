@@ -0,0 +1,98 @@
+package core
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func executeWithFuncs(t *testing.T, text string, data any) (string, error) {
+	t.Helper()
+	tmpl := template.Must(template.New("page").Funcs(FuncMap()).Parse(text))
+	var buf strings.Builder
+	err := tmpl.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func TestFuncMapFormParts(t *testing.T) {
+	type Model struct {
+		Name  string `form:"name" label:"Your name"`
+		Email string `form:"email"`
+	}
+	form := NewForm(&Model{}, "PUT", "test_form")
+	form.CSRF = "token123"
+
+	output, err := executeWithFuncs(t, `<section>{{ formStart . }}{{ formField . "name" }}{{ csrfField . }}{{ formSubmit . }}{{ formEnd }}</section>`, form)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		`<section><form id="test_form" method="POST">`,
+		`<input type="hidden" name="_method" value="PUT">`,
+		`<label for="test_form_name">Your name</label>`,
+		`<input type="hidden" name="test_form_csrf_token" value="token123">`,
+		`<button type="submit">Submit</button></form></section>`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "test_form_email") {
+		t.Errorf("Expected only the Name field, got:\n%s", output)
+	}
+}
+
+func TestFuncMapFormResponse(t *testing.T) {
+	type Model struct {
+		Name string `form:"name"`
+	}
+	form := NewForm(&Model{}, "POST", "test_form")
+
+	output, err := executeWithFuncs(t, `{{ formFields . }}`, form.ToHTMLResponse())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !strings.Contains(output, `<label for="test_form_name">name</label>`) {
+		t.Errorf("Expected Name field, got:\n%s", output)
+	}
+
+	if _, err := executeWithFuncs(t, `{{ formFields . }}`, FormResponse{}); err == nil {
+		t.Error("Expected error for FormResponse without form")
+	}
+	if _, err := executeWithFuncs(t, `{{ formField . "Missing" }}`, form); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
+
+func TestFuncMapFormErrors(t *testing.T) {
+	type Model struct {
+		Name  string `form:"name"`
+		Email string `form:"email"`
+	}
+	form := NewForm(&Model{}, "POST", "test_form")
+	form.AddError("form", "Form expired")
+	form.AddError("email", "Invalid email")
+	form.AddError("name", "Name is required")
+
+	output, err := executeWithFuncs(t, `{{ formErrors . }}`, form)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	expected := `<ul class="error" role="alert">
+    <li>Name is required</li>
+    <li>Invalid email</li>
+    <li>Form expired</li>
+</ul>`
+	if output != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, output)
+	}
+
+	output, err = executeWithFuncs(t, `{{ formErrors . }}`, NewForm(&Model{}, "POST", "test_form"))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if output != "" {
+		t.Errorf("Expected no output without errors, got %q", output)
+	}
+}
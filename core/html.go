@@ -0,0 +1,110 @@
+package core
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"net/http"
+)
+
+// formTemplate — встроенный шаблон формы, не требующий пользовательских шаблонов.
+// Части формы (goform_start, goform_field и т.д.) используются также функциями шаблонов.
+var formTemplate = template.Must(template.ParseFS(templatesFS, "templates/form.html", "templates/form_parts.html"))
+
+// htmlForm содержит данные формы, подготовленные для встроенного шаблона.
+type htmlForm struct {
+	FormID         string
+	HTTPMethod     string // Метод, поддерживаемый HTML-формой (GET или POST)
+	MethodOverride string // Исходный метод для скрытого поля _method
+	CSRF           string
+	CSRFName       string // Имя скрытого поля с CSRF-токеном
+	Theme          *Theme
+	Fields         []htmlField
+}
+
+// htmlField содержит данные поля и элемент управления, отрендеренный виджетом.
+type htmlField struct {
+	ID                 string
+	Label              string
+	Error              string
+	ErrorID            string
+	Control            template.HTML
+	Check              bool   // Чекбокс выводится перед подписью
+	SelectWrapperClass string // Класс обертки select из темы
+	Theme              *Theme
+}
+
+// HTML возвращает разметку формы, сгенерированную встроенным шаблоном.
+func (f *Form) HTML() (template.HTML, error) {
+	var buf bytes.Buffer
+	if err := f.WriteHTML(&buf); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// WriteHTML записывает разметку формы, сгенерированную встроенным шаблоном, в w.
+// Разметка сначала формируется в буфере, поэтому при ошибке в w ничего не записывается.
+func (f *Form) WriteHTML(w io.Writer) error {
+	var buf bytes.Buffer
+	if err := formTemplate.ExecuteTemplate(&buf, "form.html", f.htmlData()); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// htmlData подготавливает данные формы для встроенного шаблона.
+func (f *Form) htmlData() htmlForm {
+	data := htmlForm{
+		FormID:     f.FormID,
+		HTTPMethod: http.MethodPost,
+		CSRF:       f.CSRF,
+		CSRFName:   f.CSRFInputName(),
+		Theme:      f.theme(),
+	}
+	switch f.Method {
+	case http.MethodGet:
+		data.HTTPMethod = http.MethodGet
+	case http.MethodPost, "":
+	default:
+		data.MethodOverride = f.Method
+	}
+
+	for _, field := range f.Fields {
+		if field.Hidden {
+			continue
+		}
+		data.Fields = append(data.Fields, f.htmlField(field))
+	}
+	return data
+}
+
+// htmlField подготавливает данные поля для встроенного шаблона.
+func (f *Form) htmlField(field *Field) htmlField {
+	theme := f.theme()
+	widgetField := f.widgetField(field)
+	data := htmlField{
+		ID:      widgetField.ID,
+		Label:   field.Label,
+		Error:   field.Error,
+		ErrorID: widgetField.ErrorID,
+		Control: f.widgetFor(field).Render(widgetField),
+		Theme:   theme,
+	}
+	switch widgetName(field) {
+	case "checkbox":
+		data.Check = theme.CheckFieldClass != ""
+	case "select":
+		if theme.SelectWrapperClass != "" {
+			data.SelectWrapperClass = theme.SelectWrapperClass
+			if field.Error != "" {
+				data.SelectWrapperClass = joinClasses(data.SelectWrapperClass, theme.InvalidClass)
+			}
+		}
+	}
+	if data.Label == "" {
+		data.Label = field.Name
+	}
+	return data
+}
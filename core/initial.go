@@ -0,0 +1,169 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// SetInitial задает начальные значения полей из произвольного источника, например из базы данных
+// или строки запроса. Значения форматируются так же, как значения модели в NewForm,
+// и становятся точкой отсчета для Changed. Имена, которых нет в форме, пропускаются.
+func (f *Form) SetInitial(values map[string]any) {
+	if f.initial == nil {
+		f.initial = make(map[string]string)
+	}
+	for _, field := range f.Fields {
+		value, ok := values[field.Name]
+		if !ok {
+			continue
+		}
+		field.Value = formatValue(reflect.ValueOf(value))
+		f.initial[field.Name] = comparableValue(field)
+	}
+}
+
+// ApplyDefaults подставляет значения из тега default в пустые поля, например в форме создания записи.
+// Числовое поле с нулем тоже считается пустым: у новой записи ноль означает, что значение не задано.
+// Начальные значения для Changed и Diff не меняются: они всегда соответствуют модели,
+// поэтому поле со значением по умолчанию считается измененным.
+func (f *Form) ApplyDefaults() {
+	for _, field := range f.Fields {
+		if field.Default == "" {
+			continue
+		}
+		if value := valueToString(field.Value); value == "" || (isNumericField(field) && comparableValue(field) == "0") {
+			field.Value = field.Default
+		}
+	}
+}
+
+// Changed сообщает, отличается ли значение хотя бы одного поля от начального.
+func (f *Form) Changed() bool {
+	return len(f.ChangedFields()) > 0
+}
+
+// ChangedFields возвращает имена полей, значения которых отличаются от начальных,
+// в порядке полей формы. Обычно вызывается после Bind.
+func (f *Form) ChangedFields() []string {
+	var changed []string
+	for _, field := range f.Fields {
+		if field.Name == "" {
+			continue
+		}
+		if comparableValue(field) != f.initial[field.Name] {
+			changed = append(changed, field.Name)
+		}
+	}
+	return changed
+}
+
+// recordInitial запоминает текущие значения полей как начальные.
+func (f *Form) recordInitial() {
+	f.initial = make(map[string]string, len(f.Fields))
+	for _, field := range f.Fields {
+		f.initial[field.Name] = comparableValue(field)
+	}
+}
+
+// comparableValue возвращает значение поля для сравнения с начальным.
+// Для чекбоксов сравнивается только состояние: значения "on", "true" и "1" равнозначны.
+// Числа сравниваются по значению, как их запишет UpdateModelFromForm: "0", "" и "0.0"
+// равнозначны, как и "1.5" и "1.50". Нечисловое значение сравнивается как строка.
+func comparableValue(field *Field) string {
+	value := valueToString(field.Value)
+	if field.Type == "checkbox" {
+		if value == "on" || value == "true" || value == "1" {
+			return "true"
+		}
+		return ""
+	}
+	if !isNumericField(field) {
+		return value
+	}
+	if value == "" {
+		return "0"
+	}
+	switch field.kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return strconv.FormatInt(n, 10)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return strconv.FormatUint(n, 10)
+		}
+	default:
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	}
+	return value
+}
+
+// isNumericField сообщает, что поле хранит число: поле модели числового типа
+// или поле формы без структуры с типом number.
+func isNumericField(field *Field) bool {
+	switch field.kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Invalid:
+		return field.Type == "number"
+	}
+	return false
+}
+
+// formatValue приводит значение модели к виду, который используют поля формы:
+// скалярные значения — строки, а структуры, срезы и мапы — вложенные значения,
+// как после привязки JSON-тела. Нулевые значения дают пустую строку, кроме чисел:
+// сохраненный ноль показывается в форме редактирования как 0.
+func formatValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return ""
+	}
+	if v.IsZero() {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return "0"
+		}
+		return ""
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return formatValue(v.Elem())
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	case reflect.Bool:
+		return "true"
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		if !v.CanInterface() {
+			return ""
+		}
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		var nested interface{}
+		if err := json.Unmarshal(data, &nested); err != nil {
+			return ""
+		}
+		return nested
+	default:
+		if !v.CanInterface() {
+			return ""
+		}
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ArticleForm struct {
+	Title   string   `form:"title" validate:"required"`
+	Status  string   `form:"status" default:"draft"`
+	Views   int      `form:"views"`
+	Rating  float64  `form:"rating"`
+	Public  bool     `form:"public"`
+	Tags    []string `form:"tags"`
+	Comment string   `form:"comment"`
+}
+
+func TestNewFormInitialValues(t *testing.T) {
+	model := &ArticleForm{Title: "Hello", Views: 42, Rating: 4.5, Public: true, Tags: []string{"go"}}
+	form := NewForm(model, http.MethodPost, "article")
+
+	want := map[string]string{
+		"title":   "Hello",
+		"status":  "",
+		"views":   "42",
+		"rating":  "4.5",
+		"public":  "true",
+		"tags":    `["go"]`,
+		"comment": "",
+	}
+	for name, value := range want {
+		field := initialTestField(form, name)
+		if got := valueToString(field.Value); got != value {
+			t.Errorf("Field %s: expected '%s', got '%s'", name, value, got)
+		}
+	}
+	if form.Changed() {
+		t.Errorf("Expected no changes, got %v", form.ChangedFields())
+	}
+}
+
+func TestDefaultTagKeepsModelValue(t *testing.T) {
+	form := NewFormWithDefaults(&ArticleForm{Status: "published"}, http.MethodPost, "article")
+	field := initialTestField(form, "status")
+	if field.Value != "published" {
+		t.Errorf("Expected 'published', got '%v'", field.Value)
+	}
+}
+
+func TestSetInitial(t *testing.T) {
+	form := NewForm(&ArticleForm{}, http.MethodPost, "article")
+	form.SetInitial(map[string]any{"title": "From DB", "views": 7, "unknown": "x"})
+
+	field := initialTestField(form, "title")
+	if field.Value != "From DB" {
+		t.Errorf("Expected 'From DB', got '%v'", field.Value)
+	}
+	field = initialTestField(form, "views")
+	if field.Value != "7" {
+		t.Errorf("Expected '7', got '%v'", field.Value)
+	}
+	if form.Changed() {
+		t.Errorf("Expected no changes, got %v", form.ChangedFields())
+	}
+}
+
+func TestChangedFieldsAfterBind(t *testing.T) {
+	model := &ArticleForm{Title: "Hello", Status: "draft", Views: 42, Public: true}
+	form := NewForm(model, http.MethodPost, "article")
+
+	data := url.Values{
+		"article_title":  {"Hello, world"},
+		"article_status": {"draft"},
+		"article_views":  {"42"},
+		"article_public": {"on"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := form.Bind(req); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+
+	if !form.Changed() {
+		t.Fatal("Expected changes")
+	}
+	if got, want := form.ChangedFields(), []string{"title"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// initialTestField возвращает поле формы по имени.
+func initialTestField(form *Form, name string) *Field {
+	for _, field := range form.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return &Field{}
+}
+
+func TestNewFormWithDefaults(t *testing.T) {
+	form := NewFormWithDefaults(&ArticleForm{}, http.MethodPost, "article")
+	if field := initialTestField(form, "status"); field.Value != "draft" {
+		t.Errorf("Expected 'draft', got '%v'", field.Value)
+	}
+	// Начальные значения соответствуют модели, поэтому значение по умолчанию — изменение
+	if got, want := form.ChangedFields(), []string{"status"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestEditFormKeepsZeroValues(t *testing.T) {
+	model := &ArticleForm{Title: "Hello"} // Сохраненная запись с пустым статусом
+	form := NewForm(model, http.MethodPost, "article")
+	if field := initialTestField(form, "status"); field.Value != "" {
+		t.Fatalf("Expected the stored empty status, got '%v'", field.Value)
+	}
+
+	data := url.Values{"article_title": {"Hello"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := form.Bind(req); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if form.Changed() || len(form.Diff()) != 0 {
+		t.Errorf("Expected an unchanged form, got %v", form.Diff())
+	}
+	if err := UpdateModelFromForm(model, form); err != nil {
+		t.Fatalf("UpdateModelFromForm failed: %v", err)
+	}
+	if model.Status != "" {
+		t.Errorf("Expected the status to stay empty, got '%s'", model.Status)
+	}
+}
+
+func TestNumericValuesCompareByValue(t *testing.T) {
+	model := &ArticleForm{Title: "Hello", Views: 0, Rating: 1.5}
+	form := NewForm(model, http.MethodPost, "article")
+	if field := initialTestField(form, "views"); field.Value != "0" {
+		t.Errorf("Expected the stored zero to be shown, got '%v'", field.Value)
+	}
+
+	// Те же числа в другой записи не считаются изменением
+	if err := form.Bind(newJSONRequest(`{"title": "Hello", "views": 0, "rating": 1.50}`)); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if form.Changed() || len(form.Diff()) != 0 {
+		t.Errorf("Expected an unchanged form, got %v", form.Diff())
+	}
+
+	if err := form.Bind(newJSONRequest(`{"title": "Hello", "views": 3, "rating": 1.5}`)); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if diff := form.Diff(); !reflect.DeepEqual(diff, []FieldChange{{Field: "views", Old: "0", New: "3"}}) {
+		t.Errorf("Expected only views to change, got %v", diff)
+	}
+}
+
+func TestApplyDefaultsNumericZero(t *testing.T) {
+	type OrderForm struct {
+		Qty int `form:"qty" default:"1"`
+	}
+	form := NewFormWithDefaults(&OrderForm{}, http.MethodPost, "order")
+	if field := initialTestField(form, "qty"); field.Value != "1" {
+		t.Errorf("Expected default quantity '1', got '%v'", field.Value)
+	}
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// FormJSONVersion — версия JSON-представления формы. Версия увеличивается
+// при несовместимых изменениях формата; новые необязательные ключи версию не меняют.
+const FormJSONVersion = 1
+
+// ErrUnsupportedFormJSONVersion возвращается DecodeFormJSON для неизвестной версии формата.
+var ErrUnsupportedFormJSONVersion = errors.New("unsupported form JSON version")
+
+// FormJSON — JSON-представление формы: описание полей, значения и ошибки.
+//
+//	{
+//	  "version": 1,
+//	  "form_id": "register_form",
+//	  "method": "POST",
+//	  "csrf_token": "...",
+//	  "fields": [
+//	    {"name": "username", "input_name": "register_form_username", "label": "Username", "type": "text", "widget": "input",
+//	     "value": "jo", "rules": ["required", "min=3"], "error": "Username must be at least 3 characters"}
+//	  ],
+//	  "errors": {"username": "Username must be at least 3 characters"}
+//	}
+//
+// Поля идут в порядке объявления в модели, ключи errors — имена полей.
+// Ключи fields и errors присутствуют всегда, пустые значения остальных ключей полей опускаются.
+type FormJSON struct {
+	Version int               `json:"version"`
+	FormID  string            `json:"form_id"`
+	Method  string            `json:"method"`
+	CSRF    string            `json:"csrf_token,omitempty"`
+	Fields  []FieldJSON       `json:"fields"`
+	Errors  map[string]string `json:"errors"`
+}
+
+// FieldJSON — JSON-представление поля формы.
+type FieldJSON struct {
+	Name      string   `json:"name"`
+	InputName string   `json:"input_name"` // Имя элемента HTML-формы по стратегии имен
+	Label     string   `json:"label"`
+	Type      string   `json:"type"`
+	Widget    string   `json:"widget"`
+	Value     string   `json:"value"`
+	Hidden    bool     `json:"hidden,omitempty"`
+	Rules     []string `json:"rules,omitempty"`
+	Choices   []Choice `json:"choices,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// ToJSON возвращает JSON-представление формы.
+func (f *Form) ToJSON() FormJSON {
+	data := FormJSON{
+		Version: FormJSONVersion,
+		FormID:  f.FormID,
+		Method:  f.Method,
+		CSRF:    f.CSRF,
+		Fields:  make([]FieldJSON, 0, len(f.Fields)),
+		Errors:  make(map[string]string, len(f.Errs)),
+	}
+	for _, field := range f.Fields {
+		data.Fields = append(data.Fields, FieldJSON{
+			Name:      field.Name,
+			InputName: f.InputName(field.Name),
+			Label:     field.Label,
+			Type:      field.Type,
+			Widget:    widgetName(field),
+			Value:     valueToString(field.Value),
+			Hidden:    field.Hidden,
+			Rules:     field.Rules,
+			Choices:   field.Choices,
+			Pattern:   field.Pattern,
+			Error:     field.Error,
+		})
+	}
+	for name, msg := range f.Errs {
+		data.Errors[name] = msg
+	}
+	return data
+}
+
+// Form восстанавливает форму из JSON-представления, например на стороне Go-клиента.
+// Значения полей восстанавливаются строками, кастомные валидаторы и виджеты не передаются.
+func (d FormJSON) Form() *Form {
+	form := &Form{
+		CSRF:   d.CSRF,
+		Errs:   make(map[string]string, len(d.Errors)),
+		Method: d.Method,
+		FormID: d.FormID,
+	}
+	for _, data := range d.Fields {
+		field := NewField(data.Name, data.Type)
+		field.Label = data.Label
+		field.Value = data.Value
+		field.Error = data.Error
+		field.Hidden = data.Hidden
+		field.Rules = data.Rules
+		field.WidgetName = data.Widget
+		field.Choices = data.Choices
+		field.Pattern = data.Pattern
+		form.Fields = append(form.Fields, field)
+	}
+	for name, msg := range d.Errors {
+		form.Errs[name] = msg
+	}
+	form.recordInitial()
+	return form
+}
+
+// DecodeFormJSON читает JSON-представление формы и проверяет его версию.
+func DecodeFormJSON(r io.Reader) (*FormJSON, error) {
+	var data FormJSON
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	if data.Version != FormJSONVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedFormJSONVersion, data.Version)
+	}
+	return &data, nil
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type JSONForm struct {
+	Username string `form:"username" label:"Username" validate:"required,min=3"`
+	Country  string `form:"country" label:"Country" choices:"ru:Russia,en:England"`
+	Agree    bool   `form:"agree" label:"I agree"`
+	Internal string
+}
+
+func newJSONForm() *Form {
+	form := NewForm(&JSONForm{}, "PUT", "profile")
+	form.AddCSRFToken("token123")
+	form.Fields[0].Value = "ab"
+	form.Fields[0].Error = "Username must be at least 3 characters"
+	form.AddError("username", form.Fields[0].Error)
+	form.Fields[1].Value = "en"
+	form.Fields[2].Value = true
+	return form
+}
+
+// TestFormJSONGolden закрепляет формат JSON-представления формы.
+func TestFormJSONGolden(t *testing.T) {
+	body, err := json.MarshalIndent(newJSONForm().ToJSON(), "", "  ")
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "form_v1.json")
+	if *update {
+		if err := os.WriteFile(golden, body, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(body, expected) {
+		t.Errorf("JSON does not match %s:\n%s", golden, body)
+	}
+}
+
+func TestFormJSONRoundTrip(t *testing.T) {
+	original := newJSONForm().ToJSON()
+	body, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	decoded, err := DecodeFormJSON(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("DecodeFormJSON failed: %v", err)
+	}
+	if !reflect.DeepEqual(*decoded, original) {
+		t.Errorf("Expected %+v, got %+v", original, *decoded)
+	}
+
+	form := decoded.Form()
+	if form.FormID != "profile" || form.Method != "PUT" || form.CSRF != "token123" {
+		t.Errorf("Unexpected form attributes: %+v", form)
+	}
+	if !form.Fields[0].HasRule("required") || form.Errs["username"] == "" || !form.Fields[3].Hidden {
+		t.Errorf("Expected rules, errors and hidden fields to be restored: %+v", form.Fields)
+	}
+	if !reflect.DeepEqual(form.ToJSON(), original) {
+		t.Errorf("Expected restored form to produce the same JSON, got %+v", form.ToJSON())
+	}
+}
+
+func TestDecodeFormJSONVersion(t *testing.T) {
+	_, err := DecodeFormJSON(strings.NewReader(`{"version": 2, "fields": [], "errors": {}}`))
+	if !errors.Is(err, ErrUnsupportedFormJSONVersion) {
+		t.Errorf("Expected ErrUnsupportedFormJSONVersion, got %v", err)
+	}
+
+	if _, err := DecodeFormJSON(strings.NewReader(`{"version":`)); err == nil {
+		t.Error("Expected error for malformed JSON")
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+// NameStrategy строит имя элемента HTML-формы (атрибут name) по идентификатору формы и имени поля.
+type NameStrategy func(formID, name string) string
+
+// PrefixNames возвращает стратегию вида <form_id><separator><поле>, например register_username.
+// Если идентификатор формы пуст, используется имя поля.
+func PrefixNames(separator string) NameStrategy {
+	return func(formID, name string) string {
+		if formID == "" {
+			return name
+		}
+		return formID + separator + name
+	}
+}
+
+var (
+	// BracketNames — стратегия вида <form_id>[<поле>], например register[username].
+	BracketNames NameStrategy = func(formID, name string) string {
+		if formID == "" {
+			return name
+		}
+		return formID + "[" + name + "]"
+	}
+
+	// PlainNames — стратегия без префикса: имя элемента совпадает с именем поля.
+	PlainNames NameStrategy = func(formID, name string) string {
+		return name
+	}
+)
+
+// defaultNameStrategy — стратегия, используемая формами без собственной стратегии.
+var defaultNameStrategy = PrefixNames("_")
+
+// SetDefaultNameStrategy задает стратегию имен для всех форм, у которых не указана Form.NameStrategy.
+// nil восстанавливает стратегию по умолчанию PrefixNames("_").
+func SetDefaultNameStrategy(strategy NameStrategy) {
+	if strategy == nil {
+		strategy = PrefixNames("_")
+	}
+	defaultNameStrategy = strategy
+}
+
+// InputName возвращает имя элемента HTML-формы для поля name по стратегии имен формы.
+func (f *Form) InputName(name string) string {
+	if f.NameStrategy != nil {
+		return f.NameStrategy(f.FormID, name)
+	}
+	return defaultNameStrategy(f.FormID, name)
+}
+
+// CSRFInputName возвращает имя скрытого поля с CSRF-токеном формы.
+func (f *Form) CSRFInputName() string {
+	return f.InputName(CSRFFieldName)
+}
+
+// inputID возвращает атрибут id элемента поля: <form_id>_<поле> или имя поля, если идентификатор формы пуст.
+func (f *Form) inputID(name string) string {
+	if f.FormID == "" {
+		return name
+	}
+	return f.FormID + "_" + name
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNameStrategies(t *testing.T) {
+	tests := []struct {
+		strategy NameStrategy
+		formID   string
+		want     string
+	}{
+		{PrefixNames("_"), "register", "register_username"},
+		{PrefixNames("-"), "register", "register-username"},
+		{PrefixNames("_"), "", "username"},
+		{BracketNames, "register", "register[username]"},
+		{BracketNames, "", "username"},
+		{PlainNames, "register", "username"},
+	}
+
+	for _, tt := range tests {
+		form := &Form{FormID: tt.formID, NameStrategy: tt.strategy}
+		if got := form.InputName("username"); got != tt.want {
+			t.Errorf("Expected '%s', got '%s'", tt.want, got)
+		}
+	}
+}
+
+func TestBindBracketNames(t *testing.T) {
+	data := url.Values{"register[username]": {"john"}, "register_username": {"wrong"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	model := &TestForm{}
+	form := NewForm(model, http.MethodPost, "register")
+	form.NameStrategy = BracketNames
+	if err := form.Bind(req); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if err := UpdateModelFromForm(model, form); err != nil {
+		t.Fatalf("UpdateModelFromForm failed: %v", err)
+	}
+	if model.Username != "john" {
+		t.Errorf("Expected 'john', got '%s'", model.Username)
+	}
+}
+
+func TestNameStrategyRendering(t *testing.T) {
+	form := NewForm(&TestForm{}, http.MethodPost, "register")
+	form.NameStrategy = BracketNames
+	form.AddCSRFToken("token123")
+
+	html, err := form.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	for _, expected := range []string{
+		`id="register_username" type="text" name="register[username]"`,
+		`<input type="hidden" name="register[csrf_token]" value="token123">`,
+	} {
+		if !strings.Contains(string(html), expected) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", expected, html)
+		}
+	}
+
+	if name := form.ToJSON().Fields[0].InputName; name != "register[username]" {
+		t.Errorf("Expected JSON input name 'register[username]', got '%s'", name)
+	}
+	if name := form.ToHTMLResponse().CSRFName; name != "register[csrf_token]" {
+		t.Errorf("Expected CSRF name 'register[csrf_token]', got '%s'", name)
+	}
+}
+
+func TestNameStrategyEmptyFormID(t *testing.T) {
+	form := NewForm(&TestForm{}, http.MethodPost, "")
+	html, err := form.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	if !strings.Contains(string(html), `id="username" type="text" name="username"`) || strings.Contains(string(html), `"_username"`) {
+		t.Errorf("Expected names without separator, got:\n%s", html)
+	}
+}
+
+func TestCSRFTokenFromBracketField(t *testing.T) {
+	data := url.Values{"form_id": {"register"}, "register[csrf_token]": {"token123"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if token := CSRFTokenFromRequest(req); token != "token123" {
+		t.Errorf("Expected 'token123', got '%s'", token)
+	}
+}
+
+func TestCSRFCustomNameStrategy(t *testing.T) {
+	form := NewForm(&TestForm{}, http.MethodPost, "register")
+	form.NameStrategy = PrefixNames("-")
+	form.AddCSRFToken("token123")
+	html, err := form.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	if !strings.Contains(string(html), `<input type="hidden" name="csrf_field" value="register-csrf_token">`) {
+		t.Fatalf("Expected CSRF field name input, got:\n%s", html)
+	}
+
+	// Браузер отправляет все скрытые поля формы
+	data := url.Values{"form_id": {"register"}, "csrf_field": {"register-csrf_token"}, "register-csrf_token": {"token123"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: "token123"})
+
+	if err := VerifyCSRFToken(req); err != nil {
+		t.Errorf("Expected CSRF check to pass for custom strategy, got %v", err)
+	}
+}
+
+func TestSetDefaultNameStrategy(t *testing.T) {
+	defer SetDefaultNameStrategy(nil)
+
+	SetDefaultNameStrategy(PlainNames)
+	if name := NewForm(&TestForm{}, http.MethodPost, "register").InputName("username"); name != "username" {
+		t.Errorf("Expected 'username', got '%s'", name)
+	}
+
+	SetDefaultNameStrategy(nil)
+	if name := NewForm(&TestForm{}, http.MethodPost, "register").InputName("username"); name != "register_username" {
+		t.Errorf("Expected 'register_username', got '%s'", name)
+	}
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	MethodOverrideField  = "_method"                // Скрытое поле формы с исходным методом
+	MethodOverrideHeader = "X-HTTP-Method-Override" // Заголовок с исходным методом для AJAX-запросов
+)
+
+// DefaultOverrideMethods — методы, которые разрешено передавать через MethodOverrideField
+// и MethodOverrideHeader, если список не задан явно.
+var DefaultOverrideMethods = []string{http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// OverrideMethod возвращает метод запроса с учетом заголовка X-HTTP-Method-Override
+// и поля _method. Подмена выполняется только для POST-запросов и только на методы
+// из allowed (по умолчанию DefaultOverrideMethods); иначе возвращается r.Method.
+func OverrideMethod(r *http.Request, allowed ...string) string {
+	if r.Method != http.MethodPost {
+		return r.Method
+	}
+	if len(allowed) == 0 {
+		allowed = DefaultOverrideMethods
+	}
+
+	method := r.Header.Get(MethodOverrideHeader)
+	if method == "" {
+		// Тело читается только для HTML-форм; JSON и другие тела остаются нетронутыми
+		method = r.PostFormValue(MethodOverrideField)
+	}
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" || !contains(allowed, method) {
+		return r.Method
+	}
+	return method
+}
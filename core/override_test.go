@@ -0,0 +1,56 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestOverrideMethod(t *testing.T) {
+	tests := []struct {
+		method  string
+		field   string
+		header  string
+		allowed []string
+		want    string
+	}{
+		{http.MethodPost, "PUT", "", nil, http.MethodPut},
+		{http.MethodPost, "delete", "", nil, http.MethodDelete},
+		{http.MethodPost, "", "PATCH", nil, http.MethodPatch},
+		{http.MethodPost, "PUT", "DELETE", nil, http.MethodDelete},
+		{http.MethodPost, "CONNECT", "", nil, http.MethodPost},
+		{http.MethodPost, "PUT", "", []string{http.MethodDelete}, http.MethodPost},
+		{http.MethodGet, "", "DELETE", nil, http.MethodGet},
+		{http.MethodPost, "", "", nil, http.MethodPost},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/", strings.NewReader(url.Values{MethodOverrideField: {tt.field}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if tt.header != "" {
+			req.Header.Set(MethodOverrideHeader, tt.header)
+		}
+		if got := OverrideMethod(req, tt.allowed...); got != tt.want {
+			t.Errorf("%s with field %q and header %q: expected %s, got %s", tt.method, tt.field, tt.header, tt.want, got)
+		}
+	}
+}
+
+func TestOverrideMethodKeepsJSONBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title": "Hello"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(MethodOverrideHeader, http.MethodPatch)
+	if got := OverrideMethod(req); got != http.MethodPatch {
+		t.Fatalf("Expected PATCH, got %s", got)
+	}
+
+	form := NewForm(&ArticleForm{}, http.MethodPatch, "article")
+	if err := form.BindPartial(req); err != nil {
+		t.Fatalf("BindPartial failed: %v", err)
+	}
+	if !form.Submitted("title") {
+		t.Error("Expected JSON body to stay readable after OverrideMethod")
+	}
+}
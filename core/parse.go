@@ -2,6 +2,7 @@ package core
 
 import (
 	"reflect"
+	"strings"
 )
 
 // parseModel парсит структуру и создает поля формы.
@@ -27,9 +28,32 @@ func parseModel(val reflect.Value) []*Field {
 		hidden := tag == ""
 
 		// Создаем поле формы
-		formField := NewField(tag, getFieldType(field.Type.Kind()))
-		formField.Hidden = hidden // Устанавливаем, является ли поле скрытым
-		formField.Value = ""      // Инициализируем значение пустой строкой
+		formField := NewModelField(tag, field.Type.Kind())
+		formField.Hidden = hidden                   // Устанавливаем, является ли поле скрытым
+		formField.Value = formatValue(val.Field(i)) // Начальное значение из модели
+
+		if label := field.Tag.Get("label"); label != "" {
+			formField.Label = label
+		}
+		if validateTag := field.Tag.Get("validate"); validateTag != "" {
+			formField.Rules = strings.Split(validateTag, ",")
+		}
+		formField.Message = field.Tag.Get("validate_msg")
+		if choices := field.Tag.Get("choices"); choices != "" {
+			formField.Choices = ParseChoices(choices)
+			formField.WidgetName = "select" // Поле с вариантами по умолчанию выводится списком
+		}
+		// Значение по умолчанию подставляется только в форме новой записи (ApplyDefaults)
+		formField.Default = field.Tag.Get("default")
+		if pattern := field.Tag.Get("pattern"); pattern != "" {
+			formField.Pattern = pattern
+		}
+		if source := field.Tag.Get("source"); source != "" {
+			formField.Source = source
+		}
+		if widget := field.Tag.Get("widget"); widget != "" {
+			formField.WidgetName = widget
+		}
 
 		// Добавляем поле в список полей формы
 		fields = append(fields, formField)
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// FieldChange описывает изменение значения поля для журналов аудита.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// BindPartial привязывает данные из запроса к форме, как Bind, но меняет только поля,
+// переданные в запросе; остальные сохраняют начальные значения. Используется для PATCH-запросов.
+//
+// Браузер не отправляет неотмеченные чекбоксы, поэтому для HTML-форм с чекбоксами
+// снятие отметки через BindPartial не распознается — используйте Bind.
+func (f *Form) BindPartial(r *http.Request) error {
+	return bindForm(r, f, true)
+}
+
+// Submitted сообщает, было ли поле передано в последнем привязанном запросе.
+func (f *Form) Submitted(name string) bool {
+	return f.submitted[name]
+}
+
+// SubmittedFields возвращает имена полей, переданных в последнем привязанном запросе,
+// в порядке полей формы.
+func (f *Form) SubmittedFields() []string {
+	var names []string
+	for _, field := range f.Fields {
+		if f.submitted[field.Name] {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// ValidateSubmitted проверяет только поля, переданные в запросе, например правило required
+// не срабатывает для полей, которые PATCH-запрос не меняет.
+func (f *Form) ValidateSubmitted(model interface{}) error {
+	names := f.SubmittedFields()
+	if len(names) == 0 {
+		return nil
+	}
+	return validateForm(f, model, names...)
+}
+
+// Diff возвращает изменения полей относительно начальных значений (см. SetInitial)
+// в порядке полей формы.
+func (f *Form) Diff() []FieldChange {
+	var changes []FieldChange
+	for _, field := range f.Fields {
+		if field.Name == "" {
+			continue
+		}
+		value := comparableValue(field)
+		if old := f.initial[field.Name]; value != old {
+			changes = append(changes, FieldChange{Field: field.Name, Old: old, New: value})
+		}
+	}
+	return changes
+}
+
+// PatchModelFromForm обновляет только те поля модели, которые были переданы в запросе.
+// Остальные поля модели не меняются, в отличие от UpdateModelFromForm.
+func PatchModelFromForm(model interface{}, form *Form) error {
+	val := reflect.ValueOf(model).Elem()
+	typ := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		tag := typ.Field(i).Tag.Get("form")
+		if tag == "" || tag == "-" || !form.Submitted(tag) {
+			continue
+		}
+		for _, formField := range form.Fields {
+			if formField.Name != tag {
+				continue
+			}
+			fieldValue := val.Field(i)
+			if fieldValue.CanSet() {
+				if err := setFieldValue(fieldValue, formField.Value); err != nil {
+					return fmt.Errorf("field %s: %w", tag, err)
+				}
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// setSubmitted записывает привязанное значение поля и отмечает, было ли оно передано.
+// В частичном режиме непереданные поля не меняются.
+func (f *Form) setSubmitted(field *Field, value interface{}, ok, partial bool) {
+	if ok {
+		f.submitted[field.Name] = true
+	} else if partial {
+		return
+	}
+	field.Value = value
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBindPartialJSON(t *testing.T) {
+	model := &ArticleForm{Title: "Hello", Status: "published", Views: 42, Tags: []string{"go"}}
+	form := NewForm(model, http.MethodPatch, "article")
+
+	req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"title": "Hello, world", "views": 43}`))
+	req.Header.Set("Content-Type", "application/json")
+	if err := form.BindPartial(req); err != nil {
+		t.Fatalf("BindPartial failed: %v", err)
+	}
+
+	if got, want := form.SubmittedFields(), []string{"title", "views"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected submitted %v, got %v", want, got)
+	}
+	if field := initialTestField(form, "status"); field.Value != "published" {
+		t.Errorf("Expected status to stay 'published', got '%v'", field.Value)
+	}
+
+	if err := PatchModelFromForm(model, form); err != nil {
+		t.Fatalf("PatchModelFromForm failed: %v", err)
+	}
+	want := &ArticleForm{Title: "Hello, world", Status: "published", Views: 43, Tags: []string{"go"}}
+	if !reflect.DeepEqual(model, want) {
+		t.Errorf("Expected %+v, got %+v", want, model)
+	}
+}
+
+func TestBindPartialForm(t *testing.T) {
+	model := &ArticleForm{Title: "Hello", Comment: "first"}
+	form := NewForm(model, http.MethodPatch, "article")
+
+	data := url.Values{"article_comment": {""}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := form.BindPartial(req); err != nil {
+		t.Fatalf("BindPartial failed: %v", err)
+	}
+	if err := PatchModelFromForm(model, form); err != nil {
+		t.Fatalf("PatchModelFromForm failed: %v", err)
+	}
+
+	// Переданное пустое значение очищает поле, непереданное поле не меняется
+	if model.Comment != "" || model.Title != "Hello" {
+		t.Errorf("Unexpected model: %+v", model)
+	}
+}
+
+func TestBindRecordsSubmitted(t *testing.T) {
+	form := NewForm(&ArticleForm{Title: "Hello"}, http.MethodPost, "article")
+
+	data := url.Values{"article_views": {"1"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := form.Bind(req); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+
+	if !form.Submitted("views") || form.Submitted("title") {
+		t.Errorf("Unexpected submitted fields: %v", form.SubmittedFields())
+	}
+	// Полная привязка очищает непереданные поля
+	if field := initialTestField(form, "title"); field.Value != "" {
+		t.Errorf("Expected empty title, got '%v'", field.Value)
+	}
+}
+
+func TestValidateSubmitted(t *testing.T) {
+	model := &ArticleForm{}
+	form := NewForm(model, http.MethodPatch, "article")
+
+	req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"views": 5}`))
+	req.Header.Set("Content-Type", "application/json")
+	if err := form.BindPartial(req); err != nil {
+		t.Fatalf("BindPartial failed: %v", err)
+	}
+	if err := form.ValidateSubmitted(model); err != nil {
+		t.Errorf("Expected no errors for fields outside the request, got %v", form.Errs)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	form := NewForm(&ArticleForm{Title: "Hello", Views: 42}, http.MethodPatch, "article")
+
+	req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"title": "Bye", "views": 42, "public": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	if err := form.BindPartial(req); err != nil {
+		t.Fatalf("BindPartial failed: %v", err)
+	}
+
+	want := []FieldChange{
+		{Field: "title", Old: "Hello", New: "Bye"},
+		{Field: "public", Old: "", New: "true"},
+	}
+	if got := form.Diff(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
@@ -1,30 +1,71 @@
 package core
 
 import (
+	"bytes"
 	"fmt"
-	"github.com/labstack/echo/v4"
 	"html/template"
 	"io"
+	"io/fs"
 	"log"
-	"path/filepath"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
 )
 
-// TemplateRenderer содержит шаблоны для рендеринга
+// TemplateRenderer содержит шаблоны для рендеринга.
+//
+// Страницы — файлы *.html в корне файловой системы шаблонов. Общие макеты из layouts/*.html
+// и частичные шаблоны из partials/*.html доступны каждой странице, при этом каждая страница
+// разбирается в отдельный набор, поэтому разные страницы могут определять одни и те же блоки:
+//
+//	layouts/base.html:  <main>{{ block "content" . }}{{ end }}</main>
+//	index.html:         {{ template "base.html" . }}{{ define "content" }}...{{ end }}
 type TemplateRenderer struct {
 	Templates       *template.Template
 	DefaultTemplate string // Имя шаблона по умолчанию
+
+	// DevMode включает режим разработки: шаблоны перечитываются при изменении файлов,
+	// а ошибки разбора показываются в браузере. В обычном режиме используются шаблоны,
+	// разобранные при создании рендерера.
+	DevMode bool
+	// ReloadInterval — минимальный интервал между проверками файлов в режиме разработки.
+	// Нулевое значение означает проверку перед каждым рендерингом.
+	ReloadInterval time.Duration
+
+	fsys      fs.FS                         // Источник шаблонов
+	funcs     template.FuncMap              // Пользовательские функции шаблонов
+	mu        sync.RWMutex                  // Защищает шаблоны и состояние перезагрузки
+	pages     map[string]*template.Template // Наборы шаблонов страниц с макетами
+	signature string                        // Отпечаток файлов шаблонов на момент последнего разбора
+	lastCheck time.Time                     // Время последней проверки файлов
+	reloadErr error                         // Ошибка последнего разбора шаблонов
 }
 
-// Render выполняет рендеринг шаблона
-func (tr *TemplateRenderer) Render(w io.Writer, name string, data interface{}, c echo.Context) error {
+// Шаблоны макетов и частичных шаблонов, общие для всех страниц.
+const (
+	layoutsPattern  = "layouts/*.html"
+	partialsPattern = "partials/*.html"
+)
+
+// Execute выполняет рендеринг шаблона
+func (tr *TemplateRenderer) Execute(w io.Writer, name string, data interface{}) error {
 	// Используем переданное имя шаблона или имя по умолчанию
 	templateName := name
 	if templateName == "" {
 		templateName = tr.DefaultTemplate
 	}
 
+	tmpl, err := tr.lookup(templateName)
+	if err != nil {
+		log.Println("Error loading templates:", err)
+		return err
+	}
+
 	// Выполняем рендеринг шаблона
-	err := tr.Templates.ExecuteTemplate(w, templateName, data)
+	err = tmpl.ExecuteTemplate(w, templateName, data)
 	if err != nil {
 		log.Println("Error rendering template:", err)
 		return err
@@ -32,17 +73,255 @@ func (tr *TemplateRenderer) Render(w io.Writer, name string, data interface{}, c
 	return nil
 }
 
-// NewTemplateRenderer инициализирует и возвращает новый рендерер шаблонов
-func NewTemplateRenderer(templateDir string, defaultTemplate string) (*TemplateRenderer, error) {
-	// Используем ParseGlob для поиска шаблонов в указанной папке
-	tmpl, err := template.ParseGlob(filepath.Join(templateDir, "*.html"))
+// Render рендерит шаблон name (или шаблон по умолчанию) в net/http-обработчике.
+// Шаблон сначала выполняется в буфер, поэтому при ошибке клиент получает не обрезанную страницу,
+// а ответ со статусом 500: в режиме разработки — страницу с описанием ошибки.
+// Ответ об ошибке уже записан в w, вызывающему коду остается только вернуть или залогировать ошибку.
+func (tr *TemplateRenderer) Render(w http.ResponseWriter, r *http.Request, name string, data any) error {
+	return tr.render(w, name, data, http.StatusOK)
+}
+
+// render выполняет шаблон в буфер и отвечает с указанным статусом.
+func (tr *TemplateRenderer) render(w http.ResponseWriter, name string, data any, status int) error {
+	var buf bytes.Buffer
+	if err := tr.Execute(&buf, name, data); err != nil {
+		tr.RenderError(w, err)
+		return err
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	w.WriteHeader(status)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// RenderForm рендерит форму шаблоном по умолчанию в net/http-обработчике.
+// CSRF-токен берется из запроса или генерируется автоматически, cookie обновляется.
+// Ошибки рендеринга обрабатываются так же, как в Render.
+func (tr *TemplateRenderer) RenderForm(w http.ResponseWriter, r *http.Request, form *Form) error {
+	if _, err := EnsureCSRFToken(w, r, form); err != nil {
+		return err
+	}
+	tr.ApplyWidgetTemplates(form)
+	return tr.Render(w, r, "", form.ToHTMLResponse())
+}
+
+// RenderFormSet рендерит страницу name с несколькими формами. Шаблон получает набор форм,
+// формы в нем доступны через .Form "<form_id>", например {{ formStart (.Form "login") }}.
+// CSRF-токен записывается во все формы; статус — 422, если в отправленной форме есть ошибки.
+func (tr *TemplateRenderer) RenderFormSet(w http.ResponseWriter, r *http.Request, name string, set *FormSet) error {
+	if _, err := set.EnsureCSRFToken(w, r); err != nil {
+		return err
+	}
+	for _, form := range set.Forms() {
+		tr.ApplyWidgetTemplates(form)
+	}
+	return tr.render(w, name, set, set.Status())
+}
+
+// NewTemplateRenderer инициализирует и возвращает новый рендерер шаблонов.
+// Функции funcs подключаются к шаблонам вместе с функциями форм из FuncMap.
+func NewTemplateRenderer(templateDir string, defaultTemplate string, funcs ...template.FuncMap) (*TemplateRenderer, error) {
+	return NewTemplateRendererFS(os.DirFS(templateDir), defaultTemplate, funcs...)
+}
+
+// NewTemplateRendererFS инициализирует рендерер шаблонами *.html из произвольной файловой системы,
+// например embed.FS или LayeredFS с пользовательскими шаблонами поверх встроенных.
+func NewTemplateRendererFS(fsys fs.FS, defaultTemplate string, funcs ...template.FuncMap) (*TemplateRenderer, error) {
+	merged := template.FuncMap{}
+	for _, fm := range funcs {
+		for name, fn := range fm {
+			merged[name] = fn
+		}
+	}
+
+	tmpl, pages, err := parseTemplates(fsys, merged)
 	if err != nil {
-		return nil, fmt.Errorf("error loading templates: %v", err)
+		return nil, err
 	}
+	signature, _ := templatesSignature(fsys)
 
 	// Возвращаем рендерер с загруженными шаблонами
 	return &TemplateRenderer{
 		Templates:       tmpl,
 		DefaultTemplate: defaultTemplate,
+		fsys:            fsys,
+		funcs:           merged,
+		pages:           pages,
+		signature:       signature,
 	}, nil
 }
+
+// parseTemplates разбирает шаблоны *.html из файловой системы.
+// Возвращает общий набор со всеми шаблонами и, если есть макеты или частичные шаблоны,
+// отдельные наборы для каждой страницы.
+func parseTemplates(fsys fs.FS, funcs template.FuncMap) (*template.Template, map[string]*template.Template, error) {
+	names, err := fs.Glob(fsys, "*.html")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading templates: %v", err)
+	}
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("error loading templates: no files match *.html")
+	}
+
+	// ParseFS возвращает ошибку для шаблона без совпадений, поэтому берем только непустые
+	var shared []string
+	for _, pattern := range []string{layoutsPattern, partialsPattern} {
+		if matches, _ := fs.Glob(fsys, pattern); len(matches) > 0 {
+			shared = append(shared, pattern)
+		}
+	}
+
+	base := template.New(path.Base(names[0])).Funcs(FuncMap()).Funcs(funcs)
+	if len(shared) > 0 {
+		if base, err = base.ParseFS(fsys, shared...); err != nil {
+			return nil, nil, fmt.Errorf("error loading templates: %v", err)
+		}
+	}
+
+	var pages map[string]*template.Template
+	if len(shared) > 0 {
+		pages = make(map[string]*template.Template, len(names))
+		for _, name := range names {
+			page, err := template.Must(base.Clone()).ParseFS(fsys, name)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error loading templates: %v", err)
+			}
+			pages[path.Base(name)] = page
+		}
+	}
+
+	tmpl, err := base.ParseFS(fsys, "*.html")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading templates: %v", err)
+	}
+	return tmpl, pages, nil
+}
+
+// templatesSignature вычисляет отпечаток файлов шаблонов по именам, размерам и времени изменения.
+func templatesSignature(fsys fs.FS) (string, error) {
+	var signature strings.Builder
+	for _, pattern := range []string{"*.html", layoutsPattern, partialsPattern} {
+		names, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return "", err
+		}
+		for _, name := range names {
+			info, err := fs.Stat(fsys, name)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&signature, "%s:%d:%d;", name, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return signature.String(), nil
+}
+
+// CurrentTemplates возвращает актуальный набор шаблонов.
+// В режиме разработки шаблоны предварительно перечитываются, если файлы изменились.
+func (tr *TemplateRenderer) CurrentTemplates() *template.Template {
+	tmpl, _ := tr.lookup("")
+	return tmpl
+}
+
+// TemplateSet возвращает набор шаблонов для рендеринга страницы name:
+// отдельный набор страницы с макетами, если он есть, иначе общий набор.
+func (tr *TemplateRenderer) TemplateSet(name string) *template.Template {
+	tmpl, _ := tr.lookup(name)
+	return tmpl
+}
+
+// lookup возвращает актуальный набор шаблонов для страницы и ошибку последнего разбора.
+func (tr *TemplateRenderer) lookup(name string) (*template.Template, error) {
+	if !tr.DevMode || tr.fsys == nil {
+		tr.mu.RLock()
+		defer tr.mu.RUnlock()
+		return tr.templateSet(name), nil
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	err := tr.reload()
+	return tr.templateSet(name), err
+}
+
+// templateSet возвращает набор шаблонов страницы. Вызывается под блокировкой mu.
+func (tr *TemplateRenderer) templateSet(name string) *template.Template {
+	if page, ok := tr.pages[name]; ok {
+		return page
+	}
+	return tr.Templates
+}
+
+// reload перечитывает шаблоны, если файлы изменились. Вызывается под блокировкой mu.
+func (tr *TemplateRenderer) reload() error {
+	now := time.Now()
+	if now.Sub(tr.lastCheck) < tr.ReloadInterval {
+		return tr.reloadErr
+	}
+	tr.lastCheck = now
+
+	signature, err := templatesSignature(tr.fsys)
+	if err != nil {
+		tr.reloadErr = err
+		return err
+	}
+	if signature == tr.signature {
+		return tr.reloadErr
+	}
+	tr.signature = signature
+
+	// Файлы изменились: перечитываем шаблоны, при ошибке оставляем прежние
+	tmpl, pages, err := parseTemplates(tr.fsys, tr.funcs)
+	if err != nil {
+		log.Println("Error reloading templates:", err)
+		tr.reloadErr = err
+		return err
+	}
+	tr.Templates = tmpl
+	tr.pages = pages
+	tr.reloadErr = nil
+	return nil
+}
+
+// devErrorPage — страница с описанием ошибки шаблона для режима разработки.
+var devErrorPage = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>Template error</title>
+</head>
+<body>
+    <h1>Template error</h1>
+    <pre style="white-space: pre-wrap;">{{ . }}</pre>
+</body>
+</html>
+`))
+
+// RenderError отвечает на ошибку загрузки или выполнения шаблона статусом 500:
+// в режиме разработки — страницей с описанием ошибки, иначе — кратким текстом.
+// Используется адаптерами фреймворков, которые рендерят шаблоны в обход Render.
+func (tr *TemplateRenderer) RenderError(w http.ResponseWriter, err error) {
+	if tr.DevMode {
+		writeDevErrorPage(w, err)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// writeDevErrorPage отвечает страницей с описанием ошибки шаблона и статусом 500.
+func writeDevErrorPage(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = devErrorPage.Execute(w, err.Error())
+}
+
+// ApplyWidgetTemplates подключает к форме переопределения виджетов из шаблонов рендерера,
+// если для формы не задан другой набор шаблонов.
+func (tr *TemplateRenderer) ApplyWidgetTemplates(form *Form) {
+	if form.widgetTemplates == nil {
+		form.SetWidgetTemplates(tr.CurrentTemplates())
+	}
+}
@@ -0,0 +1,200 @@
+package core
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func writeTemplate(t *testing.T, dir, name, content string, modTime time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func executeToString(t *testing.T, renderer *TemplateRenderer) (string, error) {
+	t.Helper()
+	var buf strings.Builder
+	err := renderer.Execute(&buf, "", FormResponse{FormID: "test_form"})
+	return buf.String(), err
+}
+
+func TestTemplateRendererDevModeReload(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	writeTemplate(t, dir, "default.html", `v1 {{ .FormID }}`, start)
+
+	renderer, err := NewTemplateRenderer(dir, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	// Без режима разработки изменения не подхватываются
+	writeTemplate(t, dir, "default.html", `v2 {{ .FormID }}`, start.Add(time.Minute))
+	if out, _ := executeToString(t, renderer); out != "v1 test_form" {
+		t.Errorf("Expected cached template output, got '%s'", out)
+	}
+
+	// В режиме разработки шаблон перечитывается
+	renderer.DevMode = true
+	if out, _ := executeToString(t, renderer); out != "v2 test_form" {
+		t.Errorf("Expected reloaded template output, got '%s'", out)
+	}
+
+	// Ошибка разбора возвращается, пока шаблон не исправлен
+	writeTemplate(t, dir, "default.html", `broken {{ .FormID`, start.Add(2*time.Minute))
+	if _, err := executeToString(t, renderer); err == nil {
+		t.Error("Expected parse error, got nil")
+	}
+
+	writeTemplate(t, dir, "default.html", `v3 {{ .FormID }}`, start.Add(3*time.Minute))
+	if out, err := executeToString(t, renderer); err != nil || out != "v3 test_form" {
+		t.Errorf("Expected fixed template output, got '%s' (%v)", out, err)
+	}
+}
+
+func TestTemplateRendererReloadInterval(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	writeTemplate(t, dir, "default.html", `v1`, start)
+
+	renderer, err := NewTemplateRenderer(dir, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+	renderer.DevMode = true
+	renderer.ReloadInterval = time.Hour
+
+	// Первая проверка выполняется сразу, следующая — не раньше чем через интервал
+	if out, _ := executeToString(t, renderer); out != "v1" {
+		t.Errorf("Expected 'v1', got '%s'", out)
+	}
+	writeTemplate(t, dir, "default.html", `v2`, start.Add(time.Minute))
+	if out, _ := executeToString(t, renderer); out != "v1" {
+		t.Errorf("Expected template not to be reloaded before interval, got '%s'", out)
+	}
+}
+
+func TestTemplateRendererDevErrorPage(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	writeTemplate(t, dir, "default.html", `{{ .FormID }}`, start)
+
+	renderer, err := NewTemplateRenderer(dir, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+	renderer.DevMode = true
+	writeTemplate(t, dir, "default.html", `{{ if .FormID }}<b>`, start.Add(time.Minute))
+
+	form := NewForm(&TestForm{}, "POST", "test_form")
+	rec := httptest.NewRecorder()
+	if err := renderer.RenderForm(rec, httptest.NewRequest(http.MethodGet, "/", nil), form); err == nil {
+		t.Fatal("Expected RenderForm to return parse error")
+	}
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "<h1>Template error</h1>") || !strings.Contains(body, "default.html") {
+		t.Errorf("Expected error page describing the template error, got:\n%s", body)
+	}
+	if strings.Contains(body, "<b>") {
+		t.Error("Expected error details to be escaped")
+	}
+}
+
+func TestTemplateRendererRender(t *testing.T) {
+	fsys := fstest.MapFS{
+		"default.html": {Data: []byte(`<p>{{ .FormID }}</p>`)},
+		"broken.html":  {Data: []byte(`<p>partial {{ .Missing }}</p>`)},
+	}
+
+	renderer, err := NewTemplateRendererFS(fsys, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	rec := httptest.NewRecorder()
+	if err := renderer.Render(rec, req, "", FormResponse{FormID: "test_form"}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if rec.Code != http.StatusOK || rec.Body.String() != "<p>test_form</p>" {
+		t.Errorf("Expected default template with status 200, got %d '%s'", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("Expected HTML content type, got '%s'", ct)
+	}
+
+	// Ошибка выполнения шаблона не должна приводить к обрезанной странице
+	rec = httptest.NewRecorder()
+	if err := renderer.Render(rec, req, "broken.html", FormResponse{FormID: "test_form"}); err == nil {
+		t.Fatal("Expected Render to return execution error")
+	}
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "partial") {
+		t.Errorf("Expected no partial output, got '%s'", rec.Body.String())
+	}
+}
+
+func TestTemplateRendererLayouts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":    {Data: []byte(`<main>{{ template "header.html" . }}{{ block "content" . }}{{ end }}</main>`)},
+		"partials/header.html": {Data: []byte(`<h1>{{ .FormID }}</h1>`)},
+		"login.html":           {Data: []byte(`{{ template "base.html" . }}{{ define "content" }}login{{ end }}`)},
+		"signup.html":          {Data: []byte(`{{ template "base.html" . }}{{ define "content" }}signup{{ end }}`)},
+	}
+
+	renderer, err := NewTemplateRendererFS(fsys, "login.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	data := FormResponse{FormID: "test_form"}
+	for name, expected := range map[string]string{
+		"":            "<main><h1>test_form</h1>login</main>",
+		"signup.html": "<main><h1>test_form</h1>signup</main>",
+	} {
+		var buf strings.Builder
+		if err := renderer.Execute(&buf, name, data); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if buf.String() != expected {
+			t.Errorf("Expected '%s', got '%s'", expected, buf.String())
+		}
+	}
+}
+
+func TestTemplateRendererFuncs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"default.html": {Data: []byte(`{{ shout .FormID }}`)},
+	}
+
+	renderer, err := NewTemplateRendererFS(fsys, "default.html", template.FuncMap{"shout": strings.ToUpper})
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	output, err := executeToString(t, renderer)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if output != "TEST_FORM" {
+		t.Errorf("Expected 'TEST_FORM', got '%s'", output)
+	}
+}
@@ -0,0 +1,186 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Форматы ответа, зарегистрированные по умолчанию.
+const (
+	FormatHTML = "html"
+	FormatJSON = "json"
+)
+
+// FormatParam — параметр запроса, явно задающий формат ответа, например ?format=json.
+const FormatParam = "format"
+
+// Responder записывает ответ с данными формы в формате, для которого он зарегистрирован.
+type Responder func(w http.ResponseWriter, r *http.Request, form *Form, status int) error
+
+// responseFormat описывает зарегистрированный формат ответа.
+type responseFormat struct {
+	name       string
+	mediaTypes []string
+	responder  Responder
+}
+
+// formats содержит форматы ответа в порядке регистрации.
+var formats = []*responseFormat{
+	{name: FormatHTML, mediaTypes: []string{"text/html", "application/xhtml+xml"}, responder: respondHTML},
+	{name: FormatJSON, mediaTypes: []string{"application/json"}, responder: respondJSON},
+}
+
+// RegisterFormat регистрирует формат ответа с именем name для перечисленных типов содержимого.
+// Повторная регистрация заменяет формат. Регистрацию следует выполнять при инициализации программы.
+func RegisterFormat(name string, responder Responder, mediaTypes ...string) {
+	for _, format := range formats {
+		if format.name == name {
+			format.mediaTypes = mediaTypes
+			format.responder = responder
+			return
+		}
+	}
+	formats = append(formats, &responseFormat{name: name, mediaTypes: mediaTypes, responder: responder})
+}
+
+// lookupFormat возвращает зарегистрированный формат по имени.
+func lookupFormat(name string) (*responseFormat, bool) {
+	for _, format := range formats {
+		if format.name == name {
+			return format, true
+		}
+	}
+	return nil, false
+}
+
+// Negotiate выбирает формат ответа для запроса: параметр format, затем заголовок
+// X-Requested-With (AJAX-запросы получают JSON), затем заголовок Accept с учетом q-значений.
+// Если ни один зарегистрированный формат не подходит, используется HTML.
+func Negotiate(r *http.Request) string {
+	if name := r.URL.Query().Get(FormatParam); name != "" {
+		if _, ok := lookupFormat(name); ok {
+			return name
+		}
+	}
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		return FormatJSON
+	}
+
+	best, bestQ := FormatHTML, 0.0
+	for _, item := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		// При равных q побеждает тип, указанный в Accept раньше
+		if q <= bestQ {
+			continue
+		}
+		if name, ok := matchFormat(mediaType); ok {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// matchFormat находит формат для типа содержимого из заголовка Accept, включая шаблоны type/* и */*.
+// Шаблон type/* сопоставляется только с основным (первым) типом содержимого формата.
+func matchFormat(mediaType string) (string, bool) {
+	if mediaType == "*/*" {
+		return FormatHTML, true
+	}
+	prefix, wildcard := strings.CutSuffix(mediaType, "/*")
+	for _, format := range formats {
+		for i, candidate := range format.mediaTypes {
+			if candidate == mediaType || (wildcard && i == 0 && strings.HasPrefix(candidate, prefix+"/")) {
+				return format.name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ResponseStatus возвращает статус ответа для формы: 422, если в форме есть ошибки валидации, иначе 200.
+func ResponseStatus(form *Form) int {
+	if len(form.Errs) > 0 {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusOK
+}
+
+// Respond отвечает данными формы в формате, выбранном Negotiate, со статусом ResponseStatus.
+// HTML генерируется встроенным шаблоном формы; для рендеринга своими шаблонами
+// используйте TemplateRenderer.Respond.
+func Respond(w http.ResponseWriter, r *http.Request, form *Form) error {
+	return RespondAs(w, r, form, Negotiate(r))
+}
+
+// RespondAs отвечает данными формы в указанном формате со статусом ResponseStatus.
+func RespondAs(w http.ResponseWriter, r *http.Request, form *Form, name string) error {
+	format, ok := lookupFormat(name)
+	if !ok {
+		return fmt.Errorf("unknown response format %q", name)
+	}
+	return format.responder(w, r, form, ResponseStatus(form))
+}
+
+// Respond отвечает данными формы в формате, выбранном Negotiate. HTML рендерится шаблоном
+// по умолчанию с автоматическим CSRF-токеном, остальные форматы — как в core.Respond.
+func (tr *TemplateRenderer) Respond(w http.ResponseWriter, r *http.Request, form *Form) error {
+	name := Negotiate(r)
+	if name != FormatHTML {
+		return RespondAs(w, r, form, name)
+	}
+
+	if _, err := EnsureCSRFToken(w, r, form); err != nil {
+		return err
+	}
+	tr.ApplyWidgetTemplates(form)
+	return tr.render(w, "", form.ToHTMLResponse(), ResponseStatus(form))
+}
+
+// respondHTML отвечает разметкой формы, сгенерированной встроенным шаблоном.
+func respondHTML(w http.ResponseWriter, r *http.Request, form *Form, status int) error {
+	if _, err := EnsureCSRFToken(w, r, form); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := form.WriteHTML(&buf); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return err
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// respondJSON отвечает JSON-представлением формы (FormJSON).
+// CSRF-токен выдается так же, как для HTML: клиент получает его в csrf_token и в cookie
+// и передает в следующем запросе в заголовке X-CSRF-Token.
+func respondJSON(w http.ResponseWriter, r *http.Request, form *Form, status int) error {
+	if _, err := EnsureCSRFToken(w, r, form); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(form.ToJSON())
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
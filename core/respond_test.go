@@ -0,0 +1,158 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		headers map[string]string
+		want    string
+	}{
+		{"no headers", "/", nil, FormatHTML},
+		{"browser", "/", map[string]string{"Accept": "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"}, FormatHTML},
+		{"api client", "/", map[string]string{"Accept": "application/json"}, FormatJSON},
+		{"quality", "/", map[string]string{"Accept": "text/html;q=0.5, application/json"}, FormatJSON},
+		{"wildcard subtype", "/", map[string]string{"Accept": "application/*"}, FormatJSON},
+		{"any", "/", map[string]string{"Accept": "*/*"}, FormatHTML},
+		{"unsupported", "/", map[string]string{"Accept": "image/png"}, FormatHTML},
+		{"ajax", "/", map[string]string{"X-Requested-With": "XMLHttpRequest", "Accept": "text/html"}, FormatJSON},
+		{"override", "/?format=html", map[string]string{"X-Requested-With": "XMLHttpRequest"}, FormatHTML},
+		{"unknown override", "/?format=pdf", map[string]string{"Accept": "application/json"}, FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			if got := Negotiate(req); got != tt.want {
+				t.Errorf("Expected format '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRespond(t *testing.T) {
+	form := NewForm(&TestForm{}, http.MethodPost, "test_form")
+
+	// Форма без ошибок в HTML
+	rec := httptest.NewRecorder()
+	if err := Respond(rec, httptest.NewRequest(http.MethodGet, "/", nil), form); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("Expected HTML content type, got '%s'", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `<form id="test_form"`) || form.CSRF == "" {
+		t.Errorf("Expected form with CSRF token, got:\n%s", rec.Body.String())
+	}
+
+	// Ошибки валидации в JSON
+	form.AddError("username", "Username is required")
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	if err := Respond(rec, req, form); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", rec.Code)
+	}
+	body, err := DecodeFormJSON(rec.Body)
+	if err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if body.Errors["username"] != "Username is required" {
+		t.Errorf("Expected username error, got %v", body.Errors)
+	}
+}
+
+func TestRespondJSONIssuesCSRFToken(t *testing.T) {
+	// SPA запрашивает форму в JSON
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	if err := Respond(rec, req, NewForm(&TestForm{}, http.MethodPost, "test_form")); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	body, err := DecodeFormJSON(rec.Body)
+	if err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	cookies := rec.Result().Cookies()
+	if body.CSRF == "" || len(cookies) != 1 || cookies[0].Value != body.CSRF {
+		t.Fatalf("Expected csrf_token matching the cookie, got '%s' and %v", body.CSRF, cookies)
+	}
+
+	// Следующий запрос с токеном в заголовке проходит проверку
+	req = httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(CSRFHeaderName, body.CSRF)
+	req.AddCookie(cookies[0])
+	if err := VerifyCSRFToken(req); err != nil {
+		t.Errorf("Expected CSRF check to pass, got %v", err)
+	}
+}
+
+func TestTemplateRendererRespond(t *testing.T) {
+	fsys := fstest.MapFS{
+		"default.html": {Data: []byte(`{{ range .Fields }}{{ .Name }}={{ .Error }};{{ end }}`)},
+	}
+	renderer, err := NewTemplateRendererFS(fsys, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	form := NewForm(&TestForm{}, http.MethodPost, "test_form")
+	form.AddError("username", "required")
+	form.Fields[0].Error = "required"
+
+	rec := httptest.NewRecorder()
+	if err := renderer.Respond(rec, httptest.NewRequest(http.MethodPost, "/", nil), form); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", rec.Code)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "username=required;") {
+		t.Errorf("Expected form rendered by template, got '%s'", rec.Body.String())
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	saved := formats
+	defer func() { formats = saved }()
+	formats = append([]*responseFormat(nil), formats...)
+
+	RegisterFormat("text", func(w http.ResponseWriter, r *http.Request, form *Form, status int) error {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(status)
+		_, err := fmt.Fprintf(w, "%s: %d errors", form.FormID, len(form.Errs))
+		return err
+	}, "text/plain")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/plain")
+	rec := httptest.NewRecorder()
+	if err := Respond(rec, req, NewForm(&TestForm{}, http.MethodPost, "test_form")); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	if rec.Body.String() != "test_form: 0 errors" {
+		t.Errorf("Expected custom format, got '%s'", rec.Body.String())
+	}
+
+	if err := RespondAs(httptest.NewRecorder(), req, &Form{}, "pdf"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
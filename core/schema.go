@@ -0,0 +1,172 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// JSONSchemaDraft — идентификатор версии JSON Schema, которую генерирует Form.JSONSchema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema — JSON Schema (draft 2020-12) формы или отдельного поля.
+// Сериализуется через encoding/json; свойства сохраняют порядок полей формы.
+type JSONSchema struct {
+	Schema     string           `json:"$schema,omitempty"`
+	Title      string           `json:"title,omitempty"`
+	Type       string           `json:"type,omitempty"`
+	Format     string           `json:"format,omitempty"`
+	Enum       []interface{}    `json:"enum,omitempty"` // Значения имеют тип из Type: строка, число или булево значение
+	MinLength  *int             `json:"minLength,omitempty"`
+	MaxLength  *int             `json:"maxLength,omitempty"`
+	Pattern    string           `json:"pattern,omitempty"`
+	Properties SchemaProperties `json:"properties,omitempty"`
+	Required   []string         `json:"required,omitempty"`
+	WidgetName string           `json:"x-widget,omitempty"` // Имя виджета поля для построителей форм
+}
+
+// SchemaProperty — свойство объекта JSON Schema.
+type SchemaProperty struct {
+	Name   string
+	Schema *JSONSchema
+}
+
+// SchemaProperties — свойства объекта JSON Schema в порядке полей формы.
+type SchemaProperties []SchemaProperty
+
+// MarshalJSON сериализует свойства в JSON-объект, сохраняя их порядок.
+func (p SchemaProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, property := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(property.Name)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := json.Marshal(property.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(schema)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Property возвращает схему свойства по имени.
+func (p SchemaProperties) Property(name string) (*JSONSchema, bool) {
+	for _, property := range p {
+		if property.Name == name {
+			return property.Schema, true
+		}
+	}
+	return nil, false
+}
+
+// JSONSchema возвращает JSON Schema (draft 2020-12) данных формы: типы полей, обязательные поля,
+// ограничения длины из правил min и max, формат email, варианты из тега choices и регулярные
+// выражения из тега pattern. Скрытые поля в схему не входят.
+func (f *Form) JSONSchema() *JSONSchema {
+	schema := &JSONSchema{
+		Schema:     JSONSchemaDraft,
+		Title:      f.FormID,
+		Type:       "object",
+		Properties: SchemaProperties{},
+	}
+	for _, field := range f.Fields {
+		if field.Hidden {
+			continue
+		}
+		schema.Properties = append(schema.Properties, SchemaProperty{Name: field.Name, Schema: fieldSchema(field)})
+		if field.HasRule("required") {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	return schema
+}
+
+// fieldSchema возвращает схему значения поля.
+func fieldSchema(field *Field) *JSONSchema {
+	schema := &JSONSchema{
+		Title:      field.Label,
+		Type:       schemaType(field),
+		WidgetName: widgetName(field),
+	}
+	// В JSON Schema pattern ищется в любом месте строки, а тег pattern описывает значение целиком
+	if field.Pattern != "" {
+		schema.Pattern = anchorPattern(field.Pattern)
+	}
+	if field.HasRule("email") {
+		schema.Format = "email"
+	}
+	if schema.WidgetName == "date" {
+		schema.Format = "date"
+	}
+	schema.Enum = enumValues(field.Choices, schema.Type)
+	if len(field.Choices) > 0 && schema.Enum == nil {
+		// Варианты не приводятся к типу поля: без type схема не противоречит самой себе
+		schema.Type = ""
+		schema.Enum = enumValues(field.Choices, "string")
+	}
+
+	// Правила min и max ограничивают длину строкового значения
+	if schema.Type == "string" {
+		if param, ok := field.RuleParam("min"); ok {
+			if n, err := strconv.Atoi(param); err == nil {
+				schema.MinLength = &n
+			}
+		}
+		if param, ok := field.RuleParam("max"); ok {
+			if n, err := strconv.Atoi(param); err == nil {
+				schema.MaxLength = &n
+			}
+		}
+	}
+	return schema
+}
+
+// enumValues приводит значения вариантов к типу JSON Schema schemaType.
+// Возвращает nil, если вариантов нет или какое-то значение не приводится к типу.
+func enumValues(choices []Choice, schemaType string) []interface{} {
+	var values []interface{}
+	for _, choice := range choices {
+		var value interface{} = choice.Value
+		var err error
+		switch schemaType {
+		case "integer":
+			value, err = strconv.ParseInt(choice.Value, 10, 64)
+		case "number":
+			value, err = strconv.ParseFloat(choice.Value, 64)
+		case "boolean":
+			value, err = strconv.ParseBool(choice.Value)
+		}
+		if err != nil {
+			return nil
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// schemaType возвращает тип JSON Schema по типу поля модели или, если он неизвестен, по типу поля формы.
+func schemaType(field *Field) string {
+	switch field.kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	}
+	switch field.Type {
+	case "number":
+		return "number"
+	case "checkbox":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
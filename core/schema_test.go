@@ -0,0 +1,110 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type SchemaForm struct {
+	Username string  `form:"username" label:"Username" validate:"required,min=3,max=20" pattern:"[a-z0-9_]+"`
+	Email    string  `form:"email" label:"Email" validate:"required,email"`
+	Age      int     `form:"age" label:"Age"`
+	Rating   float64 `form:"rating" label:"Rating"`
+	Country  string  `form:"country" label:"Country" choices:"ru:Russia,en:England"`
+	Birthday string  `form:"birthday" label:"Birthday" widget:"date"`
+	Agree    bool    `form:"agree" label:"I agree" validate:"required"`
+	Internal string
+}
+
+// TestFormJSONSchemaGolden закрепляет JSON Schema, сгенерированную по модели.
+func TestFormJSONSchemaGolden(t *testing.T) {
+	body, err := json.MarshalIndent(NewForm(&SchemaForm{}, "POST", "signup").JSONSchema(), "", "  ")
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "schema_signup.json")
+	if *update {
+		if err := os.WriteFile(golden, body, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(body, expected) {
+		t.Errorf("JSON Schema does not match %s:\n%s", golden, body)
+	}
+}
+
+func TestFormJSONSchemaProperties(t *testing.T) {
+	schema := NewForm(&SchemaForm{}, "POST", "signup").JSONSchema()
+
+	if schema.Schema != JSONSchemaDraft || schema.Type != "object" {
+		t.Errorf("Unexpected schema header: %+v", schema)
+	}
+	if len(schema.Properties) != 7 {
+		t.Fatalf("Expected 7 properties without hidden fields, got %d", len(schema.Properties))
+	}
+
+	username, ok := schema.Properties.Property("username")
+	if !ok || *username.MinLength != 3 || *username.MaxLength != 20 || username.Pattern != "^(?:[a-z0-9_]+)$" {
+		t.Errorf("Unexpected username schema: %+v", username)
+	}
+	age, _ := schema.Properties.Property("age")
+	if age.Type != "integer" {
+		t.Errorf("Expected integer type for age, got '%s'", age.Type)
+	}
+	if _, ok := schema.Properties.Property("missing"); ok {
+		t.Error("Expected missing property not to be found")
+	}
+}
+
+func TestFormJSONSchemaTypedEnum(t *testing.T) {
+	type OrderForm struct {
+		Qty    int     `form:"qty" choices:"1:One,2:Two"`
+		Weight float64 `form:"weight" choices:"0.5,1.5"`
+		Size   int     `form:"size" choices:"s:Small,m:Medium"`
+		Plan   string  `form:"plan" choices:"free,pro"`
+	}
+	data, err := json.Marshal(NewForm(&OrderForm{}, "POST", "order").JSONSchema())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	for _, want := range []string{
+		`"qty":{"title":"qty","type":"integer","enum":[1,2]`,
+		`"weight":{"title":"weight","type":"number","enum":[0.5,1.5]`,
+		`"size":{"title":"size","enum":["s","m"]`,
+		`"plan":{"title":"plan","type":"string","enum":["free","pro"]`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected schema to contain %s, got %s", want, data)
+		}
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	type PatternForm struct {
+		Code string `form:"code" pattern:"[A-Z]{3}" validate_msg:"Invalid code"`
+	}
+
+	for value, valid := range map[string]bool{"ABC": true, "": true, "ABCD": false, "abc": false} {
+		model := &PatternForm{}
+		form := NewForm(model, "POST", "test_form")
+		form.Fields[0].Value = value
+		err := form.Validate(model)
+		if valid && err != nil {
+			t.Errorf("Expected %q to be valid, got %v", value, form.Errs)
+		}
+		if !valid && form.Errs["code"] != "Invalid code" {
+			t.Errorf("Expected %q to be invalid", value)
+		}
+	}
+}
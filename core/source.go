@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Источники значений полей для тега source.
+const (
+	SourceForm   = "form"   // Только тело запроса (HTML-форма или тело с зарегистрированным декодером)
+	SourceQuery  = "query"  // Строка запроса
+	SourcePath   = "path"   // Параметр пути маршрута
+	SourceHeader = "header" // Заголовок запроса
+	SourceCookie = "cookie" // Cookie
+)
+
+// PathParamExtractor возвращает значение параметра пути name для запроса.
+type PathParamExtractor func(r *http.Request, name string) string
+
+// pathParamsKey — ключ контекста запроса для извлекателя параметров пути.
+type pathParamsKey struct{}
+
+// WithPathParamExtractor возвращает запрос, параметры пути которого извлекаются функцией extractor.
+// Адаптеры фреймворков (Echo, chi, Gin, Fiber) вызывают ее перед привязкой формы.
+func WithPathParamExtractor(r *http.Request, extractor PathParamExtractor) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), pathParamsKey{}, extractor))
+}
+
+// PathParam возвращает параметр пути запроса. Если извлекатель не задан через
+// WithPathParamExtractor, используются шаблоны маршрутов net/http (Request.PathValue).
+func PathParam(r *http.Request, name string) string {
+	if extractor, ok := r.Context().Value(pathParamsKey{}).(PathParamExtractor); ok {
+		return extractor(r, name)
+	}
+	return r.PathValue(name)
+}
+
+// parseSource разбирает тег source вида "query" или "query:q" на источник и имя параметра.
+// Если имя не указано, используется имя поля.
+func parseSource(field *Field) (source, key string) {
+	source, key, _ = strings.Cut(field.Source, ":")
+	if key == "" {
+		key = field.Name
+	}
+	return source, key
+}
+
+// fromBody сообщает, берется ли значение поля из тела запроса.
+func fromBody(field *Field) bool {
+	source, _ := parseSource(field)
+	return source == "" || source == SourceForm
+}
+
+// bindSources привязывает к форме значения полей с источниками query, path, header и cookie.
+func bindSources(r *http.Request, form *Form, partial bool) error {
+	for _, field := range form.Fields {
+		if fromBody(field) {
+			continue
+		}
+		value, ok, err := sourceValue(r, form, field)
+		if err != nil {
+			return err
+		}
+		form.setSubmitted(field, value, ok, partial)
+	}
+	return nil
+}
+
+// sourceValue возвращает значение поля из его источника и сообщает, было ли оно передано.
+// Пустой параметр пути считается непереданным.
+func sourceValue(r *http.Request, form *Form, field *Field) (string, bool, error) {
+	source, key := parseSource(field)
+	switch source {
+	case SourceQuery:
+		query := r.URL.Query()
+		if values, ok := query[key]; ok {
+			return values[0], true, nil
+		}
+		// Форма с методом GET отправляет поля под именами элементов HTML-формы
+		values, ok := query[form.InputName(key)]
+		if !ok {
+			return "", false, nil
+		}
+		return values[0], true, nil
+	case SourcePath:
+		value := PathParam(r, key)
+		return value, value != "", nil
+	case SourceHeader:
+		values := r.Header.Values(key)
+		if len(values) == 0 {
+			return "", false, nil
+		}
+		return values[0], true, nil
+	case SourceCookie:
+		cookie, err := r.Cookie(key)
+		if err != nil {
+			return "", false, nil
+		}
+		return cookie.Value, true, nil
+	default:
+		return "", false, fmt.Errorf("field %s: unknown source %q", field.Name, source)
+	}
+}
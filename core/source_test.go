@@ -0,0 +1,67 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type SearchForm struct {
+	UserID   int    `form:"id" source:"path"`
+	Query    string `form:"query" source:"query:q"`
+	Page     int    `form:"page" source:"query"`
+	Version  string `form:"version" source:"header:X-Api-Version"`
+	Session  string `form:"session" source:"cookie"`
+	Comment  string `form:"comment" source:"form"`
+	Username string `form:"username"`
+}
+
+func TestBindSources(t *testing.T) {
+	var model *SearchForm
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/{id}/search", func(w http.ResponseWriter, r *http.Request) {
+		model = &SearchForm{}
+		if _, err := BindModel(r, model, http.MethodPost, "search"); err != nil {
+			t.Errorf("BindModel failed: %v", err)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users/42/search?q=golang&search_page=3&search_comment=ignored",
+		strings.NewReader("search_comment=hello&search_username=john"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Api-Version", "2")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+
+	expected := SearchForm{UserID: 42, Query: "golang", Page: 3, Version: "2", Session: "abc", Comment: "hello", Username: "john"}
+	if model == nil || *model != expected {
+		t.Errorf("Expected %+v, got %+v", expected, model)
+	}
+}
+
+func TestBindSourcesJSONBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/search?q=golang", strings.NewReader(`{"username": "john", "q": "body"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = WithPathParamExtractor(req, func(_ *http.Request, name string) string {
+		return map[string]string{"id": "7"}[name]
+	})
+
+	model := &SearchForm{}
+	if _, err := BindModel(req, model, http.MethodPost, "search"); err != nil {
+		t.Fatalf("BindModel failed: %v", err)
+	}
+	if model.UserID != 7 || model.Query != "golang" || model.Username != "john" {
+		t.Errorf("Unexpected model: %+v", model)
+	}
+}
+
+func TestBindUnknownSource(t *testing.T) {
+	type BadForm struct {
+		Name string `form:"name" source:"session"`
+	}
+	form := NewForm(&BadForm{}, http.MethodGet, "bad")
+	if err := form.Bind(httptest.NewRequest(http.MethodGet, "/", nil)); err == nil {
+		t.Error("Expected error for unknown source")
+	}
+}
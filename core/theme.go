@@ -0,0 +1,133 @@
+package core
+
+import (
+	"strings"
+)
+
+// Theme задает CSS-классы и структуру разметки, которую генерирует встроенный HTML-рендерер.
+type Theme struct {
+	Name string // Имя темы
+
+	FormClass    string // Класс элемента form
+	FieldClass   string // Класс обертки поля
+	LabelClass   string // Класс подписи поля
+	ControlClass string // Класс обертки элемента управления (пусто — без обертки)
+	ButtonClass  string // Класс кнопки отправки
+
+	InputClass         string // Класс полей input (text, number, date и т.д.)
+	TextareaClass      string // Класс textarea
+	SelectClass        string // Класс select
+	SelectWrapperClass string // Класс обертки select (пусто — без обертки)
+	InvalidClass       string // Класс, добавляемый элементу управления при ошибке
+	ErrorClass         string // Класс текста ошибки
+
+	CheckFieldClass string // Класс обертки чекбокса; если задан, подпись выводится после чекбокса
+	CheckClass      string // Класс чекбокса
+	CheckLabelClass string // Класс подписи чекбокса
+}
+
+var (
+	// ThemePlain — разметка без CSS-фреймворка.
+	ThemePlain = &Theme{
+		Name:       "plain",
+		ErrorClass: "error",
+	}
+
+	// ThemeBootstrap5 — разметка для Bootstrap 5.
+	ThemeBootstrap5 = &Theme{
+		Name:            "bootstrap5",
+		FieldClass:      "mb-3",
+		LabelClass:      "form-label",
+		ButtonClass:     "btn btn-primary",
+		InputClass:      "form-control",
+		TextareaClass:   "form-control",
+		SelectClass:     "form-select",
+		InvalidClass:    "is-invalid",
+		ErrorClass:      "invalid-feedback",
+		CheckFieldClass: "form-check mb-3",
+		CheckClass:      "form-check-input",
+		CheckLabelClass: "form-check-label",
+	}
+
+	// ThemeTailwind — разметка с утилитарными классами Tailwind CSS.
+	ThemeTailwind = &Theme{
+		Name:            "tailwind",
+		FormClass:       "space-y-4",
+		LabelClass:      "block text-sm font-medium text-gray-700",
+		ButtonClass:     "rounded-md bg-indigo-600 px-4 py-2 text-sm font-semibold text-white hover:bg-indigo-500",
+		InputClass:      "mt-1 block w-full rounded-md border border-gray-300 px-3 py-2 shadow-sm",
+		TextareaClass:   "mt-1 block w-full rounded-md border border-gray-300 px-3 py-2 shadow-sm",
+		SelectClass:     "mt-1 block w-full rounded-md border border-gray-300 px-3 py-2 shadow-sm",
+		InvalidClass:    "border-red-500",
+		ErrorClass:      "mt-1 text-sm text-red-600",
+		CheckFieldClass: "flex items-center gap-2",
+		CheckClass:      "h-4 w-4 rounded border-gray-300",
+		CheckLabelClass: "text-sm text-gray-700",
+	}
+
+	// ThemeBulma — разметка для Bulma.
+	ThemeBulma = &Theme{
+		Name:               "bulma",
+		FieldClass:         "field",
+		LabelClass:         "label",
+		ControlClass:       "control",
+		ButtonClass:        "button is-primary",
+		InputClass:         "input",
+		TextareaClass:      "textarea",
+		SelectWrapperClass: "select",
+		InvalidClass:       "is-danger",
+		ErrorClass:         "help is-danger",
+		CheckFieldClass:    "field",
+		CheckLabelClass:    "checkbox",
+	}
+)
+
+// defaultTheme — тема, используемая формами без собственной темы.
+var defaultTheme = ThemePlain
+
+// SetDefaultTheme задает тему для всех форм, у которых не указана Form.Theme.
+func SetDefaultTheme(theme *Theme) {
+	if theme == nil {
+		theme = ThemePlain
+	}
+	defaultTheme = theme
+}
+
+// theme возвращает тему формы или тему по умолчанию.
+func (f *Form) theme() *Theme {
+	if f.Theme != nil {
+		return f.Theme
+	}
+	return defaultTheme
+}
+
+// controlClass возвращает класс элемента управления для виджета с учетом ошибки.
+func (t *Theme) controlClass(widgetName string, invalid bool) string {
+	var class string
+	switch widgetName {
+	case "textarea":
+		class = t.TextareaClass
+	case "select":
+		class = t.SelectClass
+	case "checkbox":
+		class = t.CheckClass
+	default:
+		class = t.InputClass
+	}
+	// Если у select есть обертка, класс ошибки получает обертка
+	if invalid && !(widgetName == "select" && t.SelectWrapperClass != "") {
+		class = joinClasses(class, t.InvalidClass)
+	}
+	return class
+}
+
+// joinClasses объединяет непустые CSS-классы через пробел.
+func joinClasses(classes ...string) string {
+	var parts []string
+	for _, class := range classes {
+		if class != "" {
+			parts = append(parts, class)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "обновить golden-файлы")
+
+type ThemedForm struct {
+	Username string `form:"username" label:"Username" validate:"required,min=3"`
+	Bio      string `form:"bio" label:"Bio" widget:"textarea" validate:"max=200"`
+	Country  string `form:"country" label:"Country" choices:"ru:Russia,en:England"`
+	Agree    bool   `form:"agree" label:"I agree"`
+}
+
+func TestThemesGolden(t *testing.T) {
+	themes := []*Theme{ThemePlain, ThemeBootstrap5, ThemeTailwind, ThemeBulma}
+
+	for _, theme := range themes {
+		t.Run(theme.Name, func(t *testing.T) {
+			form := NewForm(&ThemedForm{}, "POST", "themed")
+			form.Theme = theme
+			form.AddCSRFToken("token123")
+			form.Fields[0].Value = "ab"
+			form.Fields[0].Error = "Username must be at least 3 characters"
+			form.Fields[2].Value = "en"
+			form.Fields[2].Error = "Choose a country"
+			form.Fields[3].Value = "on"
+
+			html, err := form.HTML()
+			if err != nil {
+				t.Fatalf("HTML failed: %v", err)
+			}
+
+			golden := filepath.Join("testdata", "theme_"+theme.Name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(html), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Failed to read golden file: %v", err)
+			}
+			if string(html) != string(expected) {
+				t.Errorf("HTML does not match %s:\n%s", golden, html)
+			}
+		})
+	}
+}
+
+func TestSetDefaultTheme(t *testing.T) {
+	defer SetDefaultTheme(nil)
+
+	SetDefaultTheme(ThemeBootstrap5)
+	form := NewForm(&ThemedForm{}, "POST", "themed")
+	if form.theme() != ThemeBootstrap5 {
+		t.Error("Expected default theme to be used by forms without a theme")
+	}
+
+	form.Theme = ThemeBulma
+	if form.theme() != ThemeBulma {
+		t.Error("Expected form theme to override default theme")
+	}
+
+	SetDefaultTheme(nil)
+	if NewForm(&ThemedForm{}, "POST", "themed").theme() != ThemePlain {
+		t.Error("Expected nil to reset default theme to plain")
+	}
+}
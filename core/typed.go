@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// TypedForm представляет форму, связанную с моделью конкретного типа.
+// T должен быть структурой с тегами form.
+type TypedForm[T any] struct {
+	*Form
+	model *T
+}
+
+// NewTypedForm создает типизированную форму с новой моделью типа T.
+// Модель новая, поэтому пустые поля получают значения из тега default.
+func NewTypedForm[T any](method, formID string) *TypedForm[T] {
+	form := NewTypedFormFrom(new(T), method, formID)
+	form.ApplyDefaults()
+	return form
+}
+
+// NewTypedFormFrom создает типизированную форму на основе существующей модели.
+func NewTypedFormFrom[T any](model *T, method, formID string) *TypedForm[T] {
+	return &TypedForm[T]{
+		Form:  NewForm(model, method, formID),
+		model: model,
+	}
+}
+
+// Bind привязывает данные из запроса к форме и обновляет модель.
+func (f *TypedForm[T]) Bind(r *http.Request) (T, error) {
+	if err := f.Form.Bind(r); err != nil {
+		return *f.model, err
+	}
+	if err := UpdateModelFromForm(f.model, f.Form); err != nil {
+		return *f.model, err
+	}
+	return *f.model, nil
+}
+
+// Validate проверяет данные формы по правилам модели.
+func (f *TypedForm[T]) Validate() error {
+	return f.Form.Validate(f.model)
+}
+
+// Model возвращает текущее значение модели.
+func (f *TypedForm[T]) Model() T {
+	return *f.model
+}
+
+// FieldOf возвращает поле формы, соответствующее полю модели, выбранному селектором,
+// например core.FieldOf(form, func(m *User) *string { return &m.Email }).
+// Возвращает nil, если поле модели не участвует в форме: помечено form:"-" или не имеет тега form.
+func FieldOf[T, V any](f *TypedForm[T], selector func(model *T) *V) *Field {
+	name, ok := fieldName(f.model, selector(f.model))
+	if !ok {
+		return nil
+	}
+	return f.FieldByName(name)
+}
+
+// ValueOf возвращает текущее значение поля формы, выбранного селектором, приведенное к типу поля модели,
+// например core.ValueOf(form, func(m *User) *int { return &m.Age }).
+// Возвращает ошибку, если поле не участвует в форме или его значение не приводится к типу V.
+func ValueOf[T, V any](f *TypedForm[T], selector func(model *T) *V) (V, error) {
+	var value V
+	field := FieldOf(f, selector)
+	if field == nil {
+		return value, fmt.Errorf("model field is not part of form %s", f.FormID)
+	}
+	if err := setFieldValue(reflect.ValueOf(&value).Elem(), field.Value); err != nil {
+		return value, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	return value, nil
+}
+
+// fieldName определяет имя поля формы по указателю на поле модели.
+func fieldName[T, V any](model *T, ptr *V) (string, bool) {
+	if ptr == nil {
+		return "", false
+	}
+	target := reflect.ValueOf(ptr)
+
+	val := reflect.ValueOf(model).Elem()
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		fieldValue := val.Field(i)
+		if fieldValue.Addr().Pointer() != target.Pointer() || fieldValue.Type() != target.Elem().Type() {
+			continue
+		}
+
+		// Поле без тега form скрыто, а form:"-" исключено из формы
+		tag := typ.Field(i).Tag.Get("form")
+		if tag == "" || tag == "-" {
+			return "", false
+		}
+		return tag, true
+	}
+	return "", false
+}
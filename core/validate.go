@@ -3,16 +3,26 @@ package core
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
 type ValidationFunc func(value string) error
 
-// validateForm проверяет данные формы.
+// isStructPointer сообщает, что модель — указатель на структуру, а не форма без структуры.
+func isStructPointer(model interface{}) bool {
+	t := reflect.TypeOf(model)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct && !reflect.ValueOf(model).IsNil()
+}
+
+// validateForm проверяет данные формы. Правила и сообщения берутся из тегов модели-структуры,
+// а для форм без структуры (модель — map[string]any или nil) — из полей формы.
 func validateForm(form *Form, model interface{}, fieldsToValidate ...string) error {
-	val := reflect.ValueOf(model).Elem()
-	typeOfModel := val.Type()
+	var typeOfModel reflect.Type
+	if isStructPointer(model) {
+		typeOfModel = reflect.TypeOf(model).Elem()
+	}
 
 	for _, field := range form.Fields {
 		// Пропуск полей, которые не нужно валидировать
@@ -20,7 +30,7 @@ func validateForm(form *Form, model interface{}, fieldsToValidate ...string) err
 			continue
 		}
 
-		value := field.Value.(string)
+		value := valueToString(field.Value)
 
 		// Вызов кастомной функции валидации
 		if field.CustomValidation != nil {
@@ -32,8 +42,11 @@ func validateForm(form *Form, model interface{}, fieldsToValidate ...string) err
 		}
 
 		// Стандартная валидация
-		rules := getValidationRules(model, field.Name)
-		customMsg := getCustomErrorMessage(typeOfModel, field.Name)
+		rules, customMsg := field.Rules, field.Message
+		if typeOfModel != nil {
+			rules = getValidationRules(model, field.Name)
+			customMsg = getCustomErrorMessage(typeOfModel, field.Name)
+		}
 
 		for _, rule := range rules {
 			switch {
@@ -65,6 +78,12 @@ func validateForm(form *Form, model interface{}, fieldsToValidate ...string) err
 				form.Errs[field.Name] = field.Error
 			}
 		}
+
+		// Проверка регулярного выражения из тега pattern; пустое значение проверяет правило required
+		if field.Pattern != "" && value != "" && !matchPattern(field.Pattern, value) {
+			field.Error = customMsg
+			form.Errs[field.Name] = field.Error
+		}
 	}
 
 	if len(form.Errs) > 0 {
@@ -102,6 +121,21 @@ func getCustomErrorMessage(modelType reflect.Type, fieldName string) string {
 	return ""
 }
 
+// matchPattern проверяет, что значение целиком соответствует регулярному выражению,
+// как атрибут pattern в HTML. Некорректное выражение считается несовпадением.
+func matchPattern(pattern, value string) bool {
+	re, err := regexp.Compile(anchorPattern(pattern))
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// anchorPattern привязывает регулярное выражение к началу и концу значения.
+func anchorPattern(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
 // containsFormatSpecifier проверяет, содержит ли строка форматирующие спецификаторы.
 func containsFormatSpecifier(s string) bool {
 	return strings.Contains(s, "%")
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"log"
+	"strconv"
+	"strings"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+// widgetTemplates — встроенные шаблоны виджетов, по одному файлу widget_<имя>.html на виджет.
+var widgetTemplates = template.Must(template.ParseFS(templatesFS, "templates/widget_*.html"))
+
+// Widget отвечает за рендеринг элемента управления поля формы.
+type Widget interface {
+	Render(field WidgetField) template.HTML
+}
+
+// WidgetField содержит поле формы и вычисленные для него HTML-атрибуты.
+type WidgetField struct {
+	*Field
+	ID        string // Атрибут id элемента
+	HTMLName  string // Атрибут name элемента
+	Value     string // Значение поля в виде строки
+	Required  bool   // Поле обязательно (правило required)
+	MinLength int    // Минимальная длина (правило min)
+	MaxLength int    // Максимальная длина (правило max)
+	ErrorID   string // id элемента с текстом ошибки, пусто если ошибки нет
+	Class     string // CSS-класс элемента управления из темы формы
+}
+
+// Checked сообщает, отмечен ли чекбокс.
+func (f WidgetField) Checked() bool {
+	return f.Value == "on" || f.Value == "true" || f.Value == "1"
+}
+
+// Choice представляет вариант выбора для полей select.
+type Choice struct {
+	Value string `json:"value"` // Значение варианта
+	Label string `json:"label"` // Подпись варианта
+}
+
+// TemplateWidget — виджет, который рендерит поле шаблоном с указанным именем.
+type TemplateWidget struct {
+	Templates *template.Template // Набор шаблонов
+	Name      string             // Имя шаблона в наборе
+}
+
+// Render выполняет рендеринг поля шаблоном виджета.
+func (w TemplateWidget) Render(field WidgetField) template.HTML {
+	var buf bytes.Buffer
+	if err := w.Templates.ExecuteTemplate(&buf, w.Name, field); err != nil {
+		log.Println("Error rendering widget:", err)
+		return ""
+	}
+	return template.HTML(strings.TrimSpace(buf.String()))
+}
+
+// widgets содержит зарегистрированные виджеты по именам.
+var widgets = map[string]Widget{
+	"input":    builtinWidget("input"),
+	"textarea": builtinWidget("textarea"),
+	"select":   builtinWidget("select"),
+	"checkbox": builtinWidget("checkbox"),
+	"date":     builtinWidget("date"),
+}
+
+// builtinWidget возвращает встроенный виджет с указанным именем.
+func builtinWidget(name string) Widget {
+	return TemplateWidget{Templates: widgetTemplates, Name: widgetTemplateName(name)}
+}
+
+// widgetTemplateName возвращает имя файла шаблона виджета, например widget_textarea.html.
+func widgetTemplateName(name string) string {
+	return "widget_" + name + ".html"
+}
+
+// RegisterWidget регистрирует виджет под указанным именем для использования в теге widget.
+// Регистрацию следует выполнять при инициализации программы.
+func RegisterWidget(name string, w Widget) {
+	widgets[name] = w
+}
+
+// LookupWidget возвращает зарегистрированный виджет по имени.
+func LookupWidget(name string) (Widget, bool) {
+	w, ok := widgets[name]
+	return w, ok
+}
+
+// defaultWidgetName возвращает имя виджета по умолчанию для типа поля.
+func defaultWidgetName(fieldType string) string {
+	switch fieldType {
+	case "checkbox", "date", "textarea", "select":
+		return fieldType
+	default:
+		return "input"
+	}
+}
+
+// SetWidget задает виджет для поля формы.
+func (f *Form) SetWidget(fieldName string, w Widget) {
+	for _, field := range f.Fields {
+		if field.Name == fieldName {
+			field.Widget = w
+			break
+		}
+	}
+}
+
+// SetWidgetTemplates задает набор шаблонов, в котором ищутся переопределения виджетов
+// (шаблоны с именами widget_<имя>.html).
+func (f *Form) SetWidgetTemplates(tmpl *template.Template) {
+	f.widgetTemplates = tmpl
+}
+
+// widgetFor выбирает виджет поля: явно заданный, переопределенный в шаблонах пользователя
+// или зарегистрированный под именем из тега widget.
+func (f *Form) widgetFor(field *Field) Widget {
+	if field.Widget != nil {
+		return field.Widget
+	}
+
+	name := widgetName(field)
+	if f.widgetTemplates != nil && f.widgetTemplates.Lookup(widgetTemplateName(name)) != nil {
+		return TemplateWidget{Templates: f.widgetTemplates, Name: widgetTemplateName(name)}
+	}
+	if w, ok := LookupWidget(name); ok {
+		return w
+	}
+	return widgets["input"]
+}
+
+// widgetName возвращает имя виджета поля из тега widget или имя по умолчанию для его типа.
+func widgetName(field *Field) string {
+	if field.WidgetName != "" {
+		return field.WidgetName
+	}
+	return defaultWidgetName(field.Type)
+}
+
+// widgetField вычисляет HTML-атрибуты поля для виджета.
+func (f *Form) widgetField(field *Field) WidgetField {
+	id := f.inputID(field.Name)
+	data := WidgetField{
+		Field:    field,
+		ID:       id,
+		HTMLName: f.InputName(field.Name),
+		Value:    valueToString(field.Value),
+		Required: field.HasRule("required"),
+		Class:    f.theme().controlClass(widgetName(field), field.Error != ""),
+	}
+	if param, ok := field.RuleParam("min"); ok {
+		data.MinLength, _ = strconv.Atoi(param)
+	}
+	if param, ok := field.RuleParam("max"); ok {
+		data.MaxLength, _ = strconv.Atoi(param)
+	}
+	if field.Error != "" {
+		data.ErrorID = id + "_error"
+	}
+	return data
+}
+
+// renderWidget рендерит элемент управления поля выбранным для него виджетом.
+func (f *Form) renderWidget(field *Field) template.HTML {
+	return f.widgetFor(field).Render(f.widgetField(field))
+}
+
+// ParseChoices разбирает тег choices вида "value:Label,value2:Label2".
+// Если подпись не указана, она совпадает со значением.
+func ParseChoices(tag string) []Choice {
+	var choices []Choice
+	for _, item := range strings.Split(tag, ",") {
+		value, label, found := strings.Cut(item, ":")
+		if !found {
+			label = value
+		}
+		choices = append(choices, Choice{Value: value, Label: label})
+	}
+	return choices
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type ProfileForm struct {
+	Bio      string `form:"bio" widget:"textarea" validate:"max=200"`
+	Country  string `form:"country" choices:"ru:Russia,en:England"`
+	Birthday string `form:"birthday" widget:"date"`
+	Agree    bool   `form:"agree"`
+	Nickname string `form:"nickname"`
+}
+
+type upperWidget struct{}
+
+func (upperWidget) Render(field WidgetField) template.HTML {
+	return template.HTML("<custom-input name=\"" + template.HTMLEscapeString(field.HTMLName) + "\"></custom-input>")
+}
+
+func TestBuiltinWidgets(t *testing.T) {
+	form := NewForm(&ProfileForm{}, "POST", "profile")
+	form.Fields[0].Value = "Hello <b>"
+	form.Fields[1].Value = "en"
+	form.Fields[3].Value = "on"
+
+	html, err := form.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+
+	expected := []string{
+		`<textarea id="profile_bio" name="profile_bio" maxlength="200">Hello &lt;b&gt;</textarea>`,
+		`<select id="profile_country" name="profile_country">`,
+		`<option value="ru">Russia</option>`,
+		`<option value="en" selected>England</option>`,
+		`<input id="profile_birthday" type="date" name="profile_birthday" value="">`,
+		`<input id="profile_agree" type="checkbox" name="profile_agree" value="on" checked>`,
+		`<input id="profile_nickname" type="text" name="profile_nickname" value="">`,
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(string(html), fragment) {
+			t.Errorf("Expected HTML to contain %s, got:\n%s", fragment, html)
+		}
+	}
+}
+
+func TestSetWidgetAndRegisterWidget(t *testing.T) {
+	RegisterWidget("upper", upperWidget{})
+	if _, ok := LookupWidget("upper"); !ok {
+		t.Fatal("Expected registered widget to be found")
+	}
+
+	form := NewForm(&ProfileForm{}, "POST", "profile")
+	form.SetWidget("nickname", upperWidget{})
+	form.Fields[2].WidgetName = "upper"
+
+	html, err := form.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	if !strings.Contains(string(html), `<custom-input name="profile_nickname"></custom-input>`) {
+		t.Errorf("Expected custom widget for nickname, got:\n%s", html)
+	}
+	if !strings.Contains(string(html), `<custom-input name="profile_birthday"></custom-input>`) {
+		t.Errorf("Expected registered widget for birthday, got:\n%s", html)
+	}
+}
+
+func TestWidgetTemplateOverride(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"default.html":         `{{ range .Fields }}{{ .Widget }}{{ end }}`,
+		"widget_textarea.html": `<div class="editor" data-name="{{ .HTMLName }}">{{ .Value }}</div>`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	renderer, err := NewTemplateRenderer(dir, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	form := NewForm(&ProfileForm{}, "POST", "profile")
+	form.RenderHTML = true
+	form.Fields[0].Value = "text"
+
+	rec := httptest.NewRecorder()
+	if err := renderer.RenderForm(rec, httptest.NewRequest(http.MethodGet, "/", nil), form); err != nil {
+		t.Fatalf("RenderForm failed: %v", err)
+	}
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `<div class="editor" data-name="profile_bio">text</div>`) {
+		t.Errorf("Expected overridden textarea widget, got:\n%s", body)
+	}
+	if !strings.Contains(body, `<input id="profile_nickname" type="text"`) {
+		t.Errorf("Expected builtin input widget for nickname, got:\n%s", body)
+	}
+}
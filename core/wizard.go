@@ -0,0 +1,354 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// Действия мастера, передаваемые кнопками формы шага.
+const (
+	WizardNext = "next" // Проверить и сохранить шаг, перейти к следующему
+	WizardBack = "back" // Вернуться к предыдущему шагу без проверки
+	WizardSkip = "skip" // Пропустить необязательный шаг
+)
+
+const (
+	WizardActionField  = "wizard_action"   // Поле формы или параметр запроса с действием мастера
+	WizardActionHeader = "X-Wizard-Action" // Заголовок с действием мастера для AJAX- и API-запросов
+)
+
+var (
+	ErrWizardStepInvalid   = errors.New("wizard step has validation errors") // Данные шага не прошли проверку
+	ErrWizardStepRequired  = errors.New("wizard step cannot be skipped")     // Попытка пропустить обязательный шаг
+	ErrUnknownWizardAction = errors.New("unknown wizard action")             // Неизвестное действие мастера
+)
+
+// wizardTemplate — встроенный шаблон шага мастера с индикатором прогресса.
+var wizardTemplate = template.Must(template.ParseFS(templatesFS, "templates/wizard.html", "templates/form_parts.html"))
+
+// WizardStep описывает шаг мастера.
+type WizardStep struct {
+	Name      string                       // Имя шага, хранится в состоянии мастера
+	Title     string                       // Заголовок шага для индикатора прогресса (по умолчанию Name)
+	Fields    []string                     // Имена полей модели (тег form), заполняемых на шаге
+	Skippable bool                         // Шаг можно пропустить
+	Condition func(model interface{}) bool // Шаг показывается, только если условие выполняется; nil — всегда
+}
+
+// Wizard — многошаговая форма над одной моделью. Каждый шаг заполняет и проверяет только свои поля,
+// а данные пройденных шагов сохраняются между запросами в хранилище Store.
+type Wizard struct {
+	ID        string           // Идентификатор мастера: FormID форм шагов и ключ в хранилище
+	Steps     []WizardStep     // Шаги в порядке прохождения
+	Store     WizardStore      // Хранилище промежуточных данных
+	Configure func(form *Form) // Настройка формы каждого шага: кастомная валидация, тема, виджеты
+}
+
+// NewWizard создает мастер с указанными шагами.
+func NewWizard(id string, store WizardStore, steps ...WizardStep) *Wizard {
+	return &Wizard{ID: id, Steps: steps, Store: store}
+}
+
+// WizardSession — состояние мастера в рамках одного запроса.
+type WizardSession struct {
+	Form *Form // Форма текущего шага
+
+	wizard *Wizard
+	model  interface{}
+	state  *WizardState
+	done   bool
+}
+
+// WizardProgress описывает прогресс прохождения мастера для шаблонов и JSON-ответов.
+type WizardProgress struct {
+	Step    string           `json:"step"`
+	Index   int              `json:"index"` // Номер текущего шага среди показываемых, начиная с 1
+	Total   int              `json:"total"`
+	Last    bool             `json:"last"`
+	CanBack bool             `json:"can_back"`
+	CanSkip bool             `json:"can_skip"`
+	Done    bool             `json:"done"`
+	Steps   []WizardStepInfo `json:"steps"`
+}
+
+// WizardStepInfo описывает шаг в индикаторе прогресса.
+type WizardStepInfo struct {
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Current   bool   `json:"current,omitempty"`
+	Completed bool   `json:"completed,omitempty"`
+}
+
+// WizardJSON — JSON-представление шага мастера: прогресс и форма текущего шага.
+type WizardJSON struct {
+	Progress WizardProgress `json:"wizard"`
+	Form     FormJSON       `json:"form"`
+}
+
+// wizardHTML содержит данные шага для встроенного шаблона.
+type wizardHTML struct {
+	Progress WizardProgress
+	Form     htmlForm
+}
+
+// Load загружает состояние мастера для запроса, заполняет модель сохраненными данными
+// и создает форму текущего шага. model должна быть указателем на структуру.
+func (wz *Wizard) Load(r *http.Request, model interface{}) (*WizardSession, error) {
+	state, err := wz.Store.Load(r, wz.ID)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		state = &WizardState{}
+	}
+	if state.Values == nil {
+		state.Values = make(map[string]interface{})
+	}
+	if err := applyModelValues(model, state.Values); err != nil {
+		return nil, err
+	}
+
+	s := &WizardSession{wizard: wz, model: model, state: state}
+	if i := s.stepIndex(state.Step); i < 0 || !s.visible(i) {
+		s.moveTo(s.next(-1))
+	}
+	s.buildForm()
+	return s, nil
+}
+
+// Submit обрабатывает действие мастера из запроса (поле wizard_action или заголовок X-Wizard-Action,
+// по умолчанию next) и сохраняет состояние. Если данные шага не прошли проверку, возвращается
+// ошибка ErrWizardStepInvalid, а ошибки полей остаются в s.Form для повторного рендеринга.
+func (s *WizardSession) Submit(w http.ResponseWriter, r *http.Request) error {
+	current := s.stepIndex(s.state.Step)
+
+	switch action := wizardAction(r); action {
+	case WizardBack:
+		if prev := s.prev(current); prev >= 0 {
+			s.moveTo(prev)
+		}
+	case WizardSkip:
+		if current < 0 || !s.wizard.Steps[current].Skippable {
+			return ErrWizardStepRequired
+		}
+		s.moveTo(s.next(current))
+	case WizardNext:
+		if err := s.Form.Bind(r); err != nil {
+			return err
+		}
+		if err := UpdateModelFromForm(s.model, s.Form); err != nil {
+			return err
+		}
+		if err := s.Form.Validate(s.model); err != nil {
+			return fmt.Errorf("%w: %v", ErrWizardStepInvalid, err)
+		}
+		for _, field := range s.Form.Fields {
+			s.state.Values[field.Name] = field.Value
+		}
+		if current >= 0 && !contains(s.state.Completed, s.state.Step) {
+			s.state.Completed = append(s.state.Completed, s.state.Step)
+		}
+		s.moveTo(s.next(current))
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownWizardAction, action)
+	}
+
+	s.buildForm()
+	return s.wizard.Store.Save(w, r, s.wizard.ID, s.state)
+}
+
+// Done сообщает, пройдены ли все шаги мастера. Модель в этом случае заполнена данными всех шагов.
+func (s *WizardSession) Done() bool {
+	return s.done
+}
+
+// Clear удаляет сохраненное состояние мастера, например после сохранения модели.
+func (s *WizardSession) Clear(w http.ResponseWriter, r *http.Request) error {
+	return s.wizard.Store.Clear(w, r, s.wizard.ID)
+}
+
+// Progress возвращает прогресс прохождения мастера. Шаги, условие которых не выполняется, не учитываются.
+func (s *WizardSession) Progress() WizardProgress {
+	current := s.stepIndex(s.state.Step)
+	progress := WizardProgress{
+		Step:    s.state.Step,
+		Done:    s.done,
+		CanBack: !s.done && s.prev(current) >= 0,
+		Steps:   []WizardStepInfo{},
+	}
+	if current >= 0 && !s.done {
+		progress.CanSkip = s.wizard.Steps[current].Skippable
+		progress.Last = s.next(current) < 0
+	}
+
+	for i, step := range s.wizard.Steps {
+		if !s.visible(i) {
+			continue
+		}
+		info := WizardStepInfo{
+			Name:      step.Name,
+			Title:     step.Title,
+			Current:   i == current && !s.done,
+			Completed: contains(s.state.Completed, step.Name),
+		}
+		if info.Title == "" {
+			info.Title = step.Name
+		}
+		progress.Steps = append(progress.Steps, info)
+		if i == current {
+			progress.Index = len(progress.Steps)
+		}
+	}
+	progress.Total = len(progress.Steps)
+	return progress
+}
+
+// ToJSON возвращает JSON-представление текущего шага мастера.
+func (s *WizardSession) ToJSON() WizardJSON {
+	return WizardJSON{Progress: s.Progress(), Form: s.Form.ToJSON()}
+}
+
+// WriteHTML записывает разметку текущего шага (индикатор прогресса и форму с кнопками
+// «Назад», «Пропустить» и «Далее»), сгенерированную встроенным шаблоном, в w.
+func (s *WizardSession) WriteHTML(w io.Writer) error {
+	var buf bytes.Buffer
+	data := wizardHTML{Progress: s.Progress(), Form: s.Form.htmlData()}
+	if err := wizardTemplate.ExecuteTemplate(&buf, "wizard.html", data); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// Respond отвечает текущим шагом мастера в формате, выбранном Negotiate, со статусом ResponseStatus:
+// HTML — встроенным шаблоном мастера, JSON — представлением WizardJSON, остальные форматы — формой шага.
+func (s *WizardSession) Respond(w http.ResponseWriter, r *http.Request) error {
+	status := ResponseStatus(s.Form)
+	switch name := Negotiate(r); name {
+	case FormatHTML:
+		if _, err := EnsureCSRFToken(w, r, s.Form); err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := s.WriteHTML(&buf); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return err
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		_, err := buf.WriteTo(w)
+		return err
+	case FormatJSON:
+		body, err := json.Marshal(s.ToJSON())
+		if err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, err = w.Write(body)
+		return err
+	default:
+		return RespondAs(w, r, s.Form, name)
+	}
+}
+
+// buildForm создает форму текущего шага из полей модели, перечисленных в шаге.
+func (s *WizardSession) buildForm() {
+	form := NewForm(s.model, http.MethodPost, s.wizard.ID)
+	current := s.stepIndex(s.state.Step)
+
+	var fields []*Field
+	for _, field := range form.Fields {
+		if current >= 0 && !s.done && contains(s.wizard.Steps[current].Fields, field.Name) {
+			fields = append(fields, field)
+		}
+	}
+	form.Fields = fields
+	form.recordInitial()
+
+	if s.wizard.Configure != nil {
+		s.wizard.Configure(form)
+	}
+	s.Form = form
+}
+
+// moveTo делает текущим шаг с индексом i; отрицательный индекс означает, что мастер пройден.
+func (s *WizardSession) moveTo(i int) {
+	if i < 0 {
+		s.done = true
+		return
+	}
+	s.done = false
+	s.state.Step = s.wizard.Steps[i].Name
+}
+
+// stepIndex возвращает индекс шага по имени или -1.
+func (s *WizardSession) stepIndex(name string) int {
+	for i, step := range s.wizard.Steps {
+		if step.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// visible сообщает, показывается ли шаг с учетом его условия.
+func (s *WizardSession) visible(i int) bool {
+	condition := s.wizard.Steps[i].Condition
+	return condition == nil || condition(s.model)
+}
+
+// next возвращает индекс следующего показываемого шага после i или -1.
+func (s *WizardSession) next(i int) int {
+	for j := i + 1; j < len(s.wizard.Steps); j++ {
+		if s.visible(j) {
+			return j
+		}
+	}
+	return -1
+}
+
+// prev возвращает индекс предыдущего показываемого шага перед i или -1.
+func (s *WizardSession) prev(i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if s.visible(j) {
+			return j
+		}
+	}
+	return -1
+}
+
+// wizardAction возвращает действие мастера из запроса; по умолчанию next.
+func wizardAction(r *http.Request) string {
+	if action := r.Header.Get(WizardActionHeader); action != "" {
+		return action
+	}
+	if action := r.FormValue(WizardActionField); action != "" {
+		return action
+	}
+	return WizardNext
+}
+
+// applyModelValues записывает сохраненные значения полей в модель.
+func applyModelValues(model interface{}, values map[string]interface{}) error {
+	val := reflect.ValueOf(model).Elem()
+	typ := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		tag := typ.Field(i).Tag.Get("form")
+		value, ok := values[tag]
+		if tag == "" || tag == "-" || !ok || !val.Field(i).CanSet() {
+			continue
+		}
+		if err := setFieldValue(val.Field(i), value); err != nil {
+			return fmt.Errorf("field %s: %w", tag, err)
+		}
+	}
+	return nil
+}
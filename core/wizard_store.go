@@ -0,0 +1,266 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WizardCookiePrefix — префикс имени cookie, в которой хранилища мастера держат состояние или его ключ.
+// Полное имя — префикс и идентификатор мастера.
+const WizardCookiePrefix = "goform_wizard_"
+
+// DefaultWizardStateTTL — срок, в течение которого хранилища мастера принимают сохраненное состояние.
+const DefaultWizardStateTTL = 24 * time.Hour
+
+// MinWizardSecretSize — наименьшая длина ключа подписи CookieWizardStore в байтах.
+const MinWizardSecretSize = 32
+
+// maxWizardCookieSize — предел размера cookie, который браузеры гарантированно сохраняют.
+const maxWizardCookieSize = 4096
+
+// ErrWizardStateTooLarge возвращается CookieWizardStore, если состояние не помещается в cookie.
+var ErrWizardStateTooLarge = errors.New("wizard state is too large for a cookie")
+
+// WizardState — промежуточные данные мастера, сохраняемые между запросами.
+type WizardState struct {
+	Step      string                 `json:"step"`                // Имя текущего шага
+	Completed []string               `json:"completed,omitempty"` // Имена пройденных шагов
+	Values    map[string]interface{} `json:"values,omitempty"`    // Значения полей пройденных шагов
+}
+
+// WizardStore хранит состояние мастера между запросами.
+// Load возвращает nil без ошибки, если сохраненного состояния нет.
+type WizardStore interface {
+	Load(r *http.Request, wizardID string) (*WizardState, error)
+	Save(w http.ResponseWriter, r *http.Request, wizardID string, state *WizardState) error
+	Clear(w http.ResponseWriter, r *http.Request, wizardID string) error
+}
+
+// CookieWizardStore хранит состояние мастера в cookie, подписанной HMAC-SHA256.
+// Данные не шифруются, поэтому не храните в мастере секреты; размер состояния ограничен 4 КБ.
+// В подписанные данные входит время сохранения, поэтому старую cookie нельзя предъявить позже TTL.
+type CookieWizardStore struct {
+	TTL time.Duration // Срок действия сохраненного состояния (по умолчанию DefaultWizardStateTTL)
+
+	secret []byte
+	now    func() time.Time
+}
+
+// cookieWizardPayload — подписываемое содержимое cookie мастера.
+type cookieWizardPayload struct {
+	IssuedAt int64       `json:"iat"`   // Время сохранения в секундах Unix
+	State    WizardState `json:"state"` // Состояние мастера
+}
+
+// NewCookieWizardStore создает хранилище с ключом подписи secret.
+// Ключ короче MinWizardSecretSize байт легко подобрать, поэтому такой ключ вызывает панику.
+func NewCookieWizardStore(secret []byte) *CookieWizardStore {
+	if len(secret) < MinWizardSecretSize {
+		panic(fmt.Sprintf("goform: wizard cookie secret must be at least %d bytes, got %d", MinWizardSecretSize, len(secret)))
+	}
+	return &CookieWizardStore{TTL: DefaultWizardStateTTL, secret: secret, now: time.Now}
+}
+
+// Load читает состояние из cookie. Cookie с неверной подписью или старше TTL игнорируется.
+func (s *CookieWizardStore) Load(r *http.Request, wizardID string) (*WizardState, error) {
+	cookie, err := r.Cookie(WizardCookiePrefix + wizardID)
+	if err != nil {
+		return nil, nil
+	}
+	payload, signature, ok := strings.Cut(cookie.Value, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.sign(wizardID, payload))) {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, nil
+	}
+	var stored cookieWizardPayload
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, nil
+	}
+	issuedAt := time.Unix(stored.IssuedAt, 0)
+	if s.TTL > 0 && s.now().Sub(issuedAt) > s.TTL {
+		return nil, nil
+	}
+	return &stored.State, nil
+}
+
+// Save записывает подписанное состояние в cookie ответа.
+func (s *CookieWizardStore) Save(w http.ResponseWriter, r *http.Request, wizardID string, state *WizardState) error {
+	data, err := json.Marshal(cookieWizardPayload{IssuedAt: s.now().Unix(), State: *state})
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	value := payload + "." + s.sign(wizardID, payload)
+	if len(value) > maxWizardCookieSize {
+		return ErrWizardStateTooLarge
+	}
+	cookie := newWizardCookie(r, wizardID, value)
+	cookie.MaxAge = int(s.TTL / time.Second)
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+// Clear удаляет cookie с состоянием.
+func (s *CookieWizardStore) Clear(w http.ResponseWriter, r *http.Request, wizardID string) error {
+	cookie := newWizardCookie(r, wizardID, "")
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+// sign возвращает подпись данных; идентификатор мастера не дает подставить cookie другого мастера.
+func (s *CookieWizardStore) sign(wizardID, payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(wizardID + "." + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// MemoryWizardStore хранит состояние мастера в памяти процесса, а в cookie — только случайный ключ.
+// Подходит для разработки и приложений из одного экземпляра; состояние теряется при перезапуске.
+// Состояние, не сохранявшееся дольше TTL, удаляется.
+type MemoryWizardStore struct {
+	TTL time.Duration // Срок хранения состояния после последнего сохранения (по умолчанию DefaultWizardStateTTL)
+
+	mu        sync.Mutex
+	states    map[string]memoryWizardEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// memoryWizardEntry — сохраненное состояние и время, после которого оно удаляется.
+type memoryWizardEntry struct {
+	state   WizardState
+	expires time.Time
+}
+
+// NewMemoryWizardStore создает хранилище состояния в памяти.
+func NewMemoryWizardStore() *MemoryWizardStore {
+	return &MemoryWizardStore{TTL: DefaultWizardStateTTL, states: make(map[string]memoryWizardEntry), now: time.Now}
+}
+
+// Load возвращает копию состояния по ключу из cookie.
+func (s *MemoryWizardStore) Load(r *http.Request, wizardID string) (*WizardState, error) {
+	cookie, err := r.Cookie(WizardCookiePrefix + wizardID)
+	if err != nil {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.lookup(wizardID + "." + cookie.Value)
+	if !ok {
+		return nil, nil
+	}
+	return cloneWizardState(entry.state), nil
+}
+
+// Save сохраняет копию состояния и продлевает срок его хранения.
+// Ключ из cookie используется, только если хранилище само его выдало и состояние не истекло;
+// иначе выдается новый ключ, поэтому клиент не может выбрать ключ сам.
+func (s *MemoryWizardStore) Save(w http.ResponseWriter, r *http.Request, wizardID string, state *WizardState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+
+	var key string
+	if cookie, err := r.Cookie(WizardCookiePrefix + wizardID); err == nil {
+		if _, ok := s.lookup(wizardID + "." + cookie.Value); ok {
+			key = cookie.Value
+		}
+	}
+	if key == "" {
+		randomBytes := make([]byte, 32)
+		if _, err := rand.Read(randomBytes); err != nil {
+			return err
+		}
+		key = base64.RawURLEncoding.EncodeToString(randomBytes)
+		http.SetCookie(w, newWizardCookie(r, wizardID, key))
+	}
+
+	entry := memoryWizardEntry{state: *cloneWizardState(*state)}
+	if s.TTL > 0 {
+		entry.expires = s.now().Add(s.TTL)
+	}
+	s.states[wizardID+"."+key] = entry
+	return nil
+}
+
+// lookup возвращает неистекшее состояние по ключу. Вызывается под s.mu.
+func (s *MemoryWizardStore) lookup(key string) (memoryWizardEntry, bool) {
+	entry, ok := s.states[key]
+	if !ok || s.expired(entry) {
+		return memoryWizardEntry{}, false
+	}
+	return entry, true
+}
+
+// expired сообщает, что срок хранения состояния истек.
+func (s *MemoryWizardStore) expired(entry memoryWizardEntry) bool {
+	return !entry.expires.IsZero() && s.now().After(entry.expires)
+}
+
+// sweep удаляет истекшие состояния не чаще одного раза за TTL. Вызывается под s.mu.
+func (s *MemoryWizardStore) sweep() {
+	now := s.now()
+	if s.TTL <= 0 || now.Sub(s.lastSweep) < s.TTL {
+		return
+	}
+	s.lastSweep = now
+	for key, entry := range s.states {
+		if s.expired(entry) {
+			delete(s.states, key)
+		}
+	}
+}
+
+// Clear удаляет состояние и cookie с ключом.
+func (s *MemoryWizardStore) Clear(w http.ResponseWriter, r *http.Request, wizardID string) error {
+	if cookie, err := r.Cookie(WizardCookiePrefix + wizardID); err == nil {
+		s.mu.Lock()
+		delete(s.states, wizardID+"."+cookie.Value)
+		s.mu.Unlock()
+	}
+	cookie := newWizardCookie(r, wizardID, "")
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+// cloneWizardState копирует состояние, чтобы изменения в запросе не затрагивали хранилище.
+// Вложенные значения полей не изменяются после привязки, поэтому копируются по ссылке.
+func cloneWizardState(state WizardState) *WizardState {
+	clone := WizardState{
+		Step:      state.Step,
+		Completed: append([]string(nil), state.Completed...),
+		Values:    make(map[string]interface{}, len(state.Values)),
+	}
+	for name, value := range state.Values {
+		clone.Values[name] = value
+	}
+	return &clone
+}
+
+// newWizardCookie создает cookie мастера для ответа на запрос r.
+// Признак Secure задается SetSecureCookies, как у cookie с CSRF-токеном.
+func newWizardCookie(r *http.Request, wizardID, value string) *http.Cookie {
+	return &http.Cookie{
+		Name:     WizardCookiePrefix + wizardID,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,299 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type OnboardingForm struct {
+	Name        string `form:"name" validate:"required" validate_msg:"Name is required"`
+	Email       string `form:"email" validate:"required,email" validate_msg:"Invalid email"`
+	Company     bool   `form:"company"`
+	CompanyName string `form:"company_name" validate:"required" validate_msg:"Company name is required"`
+	Newsletter  bool   `form:"newsletter"`
+}
+
+// testWizardSecret — ключ подписи CookieWizardStore минимально допустимой длины.
+var testWizardSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newOnboardingWizard(store WizardStore) *Wizard {
+	return NewWizard("onboarding", store,
+		WizardStep{Name: "account", Title: "Account", Fields: []string{"name", "email"}},
+		WizardStep{Name: "type", Title: "Account type", Fields: []string{"company"}},
+		WizardStep{Name: "company", Title: "Company", Fields: []string{"company_name"}, Condition: func(model interface{}) bool {
+			return model.(*OnboardingForm).Company
+		}},
+		WizardStep{Name: "extras", Title: "Extras", Fields: []string{"newsletter"}, Skippable: true},
+	)
+}
+
+// wizardClient отправляет запросы мастеру, сохраняя cookie между ними, как браузер.
+type wizardClient struct {
+	t       *testing.T
+	wizard  *Wizard
+	cookies map[string]*http.Cookie
+}
+
+func (c *wizardClient) submit(data url.Values) (*WizardSession, *OnboardingForm, error) {
+	c.t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+
+	model := &OnboardingForm{}
+	session, err := c.wizard.Load(req, model)
+	if err != nil {
+		c.t.Fatalf("Load failed: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	err = session.Submit(rec, req)
+	for _, cookie := range rec.Result().Cookies() {
+		c.cookies[cookie.Name] = cookie
+	}
+	return session, model, err
+}
+
+func TestWizardFlow(t *testing.T) {
+	client := &wizardClient{t: t, wizard: newOnboardingWizard(NewCookieWizardStore(testWizardSecret)), cookies: map[string]*http.Cookie{}}
+
+	session, _, err := client.submit(url.Values{"onboarding_email": {"john@example.com"}})
+	if !errors.Is(err, ErrWizardStepInvalid) {
+		t.Fatalf("Expected ErrWizardStepInvalid, got %v", err)
+	}
+	if session.Form.Errs["name"] != "Name is required" || session.Progress().Step != "account" {
+		t.Errorf("Unexpected step state: %s %v", session.Progress().Step, session.Form.Errs)
+	}
+
+	session, _, err = client.submit(url.Values{"onboarding_name": {"John"}, "onboarding_email": {"john@example.com"}})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if progress := session.Progress(); progress.Step != "type" || progress.Index != 2 || progress.Total != 3 {
+		t.Errorf("Unexpected progress: %+v", progress)
+	}
+	if len(session.Form.Fields) != 1 || session.Form.Fields[0].Name != "company" {
+		t.Errorf("Expected only the company field, got %d fields", len(session.Form.Fields))
+	}
+
+	// Условный шаг появляется после выбора типа аккаунта
+	session, _, _ = client.submit(url.Values{"onboarding_company": {"on"}})
+	if progress := session.Progress(); progress.Step != "company" || progress.Total != 4 {
+		t.Errorf("Unexpected progress: %+v", progress)
+	}
+
+	session, _, _ = client.submit(url.Values{WizardActionField: {WizardBack}})
+	if session.Progress().Step != "type" {
+		t.Errorf("Expected step 'type', got '%s'", session.Progress().Step)
+	}
+
+	client.submit(url.Values{"onboarding_company": {"on"}})
+	session, _, _ = client.submit(url.Values{"onboarding_company_name": {"Acme"}})
+	if progress := session.Progress(); progress.Step != "extras" || !progress.CanSkip || !progress.Last {
+		t.Errorf("Unexpected progress: %+v", progress)
+	}
+
+	session, model, err := client.submit(url.Values{WizardActionField: {WizardSkip}})
+	if err != nil {
+		t.Fatalf("Skip failed: %v", err)
+	}
+	if !session.Done() {
+		t.Fatal("Expected wizard to be done")
+	}
+	want := OnboardingForm{Name: "John", Email: "john@example.com", Company: true, CompanyName: "Acme"}
+	if *model != want {
+		t.Errorf("Expected %+v, got %+v", want, *model)
+	}
+}
+
+func TestWizardSkipRequiredStep(t *testing.T) {
+	client := &wizardClient{t: t, wizard: newOnboardingWizard(NewMemoryWizardStore()), cookies: map[string]*http.Cookie{}}
+
+	if _, _, err := client.submit(url.Values{WizardActionField: {WizardSkip}}); !errors.Is(err, ErrWizardStepRequired) {
+		t.Errorf("Expected ErrWizardStepRequired, got %v", err)
+	}
+	if _, _, err := client.submit(url.Values{WizardActionField: {"jump"}}); !errors.Is(err, ErrUnknownWizardAction) {
+		t.Errorf("Expected ErrUnknownWizardAction, got %v", err)
+	}
+}
+
+func TestMemoryWizardStore(t *testing.T) {
+	client := &wizardClient{t: t, wizard: newOnboardingWizard(NewMemoryWizardStore()), cookies: map[string]*http.Cookie{}}
+
+	client.submit(url.Values{"onboarding_name": {"John"}, "onboarding_email": {"john@example.com"}})
+	cookie := client.cookies[WizardCookiePrefix+"onboarding"]
+	if cookie == nil || strings.Contains(cookie.Value, "John") {
+		t.Fatalf("Expected an opaque key cookie, got %+v", cookie)
+	}
+
+	session, model, _ := client.submit(url.Values{"onboarding_company": {""}})
+	if session.Progress().Step != "extras" || model.Name != "John" {
+		t.Errorf("Expected restored state, got step '%s' and %+v", session.Progress().Step, model)
+	}
+}
+
+func TestMemoryWizardStoreKeys(t *testing.T) {
+	store := NewMemoryWizardStore()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	save := func(cookie *http.Cookie) *http.Cookie {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		if err := store.Save(rec, req, "onboarding", &WizardState{Step: "extras"}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if cookies := rec.Result().Cookies(); len(cookies) > 0 {
+			return cookies[0]
+		}
+		return nil
+	}
+
+	// Ключ, выбранный клиентом, не принимается
+	chosen := &http.Cookie{Name: WizardCookiePrefix + "onboarding", Value: "attacker-key"}
+	issued := save(chosen)
+	if issued == nil || issued.Value == chosen.Value {
+		t.Fatalf("Expected a new key instead of the client one, got %+v", issued)
+	}
+	if _, ok := store.states["onboarding.attacker-key"]; ok {
+		t.Error("Expected no state under the client key")
+	}
+
+	// Выданный ключ используется повторно без новой cookie
+	if cookie := save(issued); cookie != nil {
+		t.Errorf("Expected the issued key to be reused, got new cookie %+v", cookie)
+	}
+
+	// После TTL состояние недоступно и удаляется при следующем сохранении
+	now = now.Add(DefaultWizardStateTTL + time.Minute)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(issued)
+	if state, _ := store.Load(req, "onboarding"); state != nil {
+		t.Errorf("Expected expired state to be ignored, got %+v", state)
+	}
+	if cookie := save(issued); cookie == nil || cookie.Value == issued.Value {
+		t.Errorf("Expected a new key after expiry, got %+v", cookie)
+	}
+	if len(store.states) != 1 {
+		t.Errorf("Expected expired states to be evicted, got %d states", len(store.states))
+	}
+}
+
+func TestCookieWizardStoreRejectsTampering(t *testing.T) {
+	store := NewCookieWizardStore(testWizardSecret)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := store.Save(rec, req, "onboarding", &WizardState{Step: "extras"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	cookie := rec.Result().Cookies()[0]
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookie)
+	if state, _ := store.Load(req, "onboarding"); state == nil || state.Step != "extras" {
+		t.Errorf("Expected saved state, got %+v", state)
+	}
+
+	for _, tampered := range []*http.Cookie{
+		{Name: cookie.Name, Value: "x" + cookie.Value},
+		{Name: WizardCookiePrefix + "other", Value: cookie.Value},
+	} {
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(tampered)
+		wizardID := strings.TrimPrefix(tampered.Name, WizardCookiePrefix)
+		if state, _ := store.Load(req, wizardID); state != nil {
+			t.Errorf("Expected tampered cookie to be ignored, got %+v", state)
+		}
+	}
+}
+
+func TestCookieWizardStoreExpiry(t *testing.T) {
+	store := NewCookieWizardStore(testWizardSecret)
+	issued := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return issued }
+
+	rec := httptest.NewRecorder()
+	if err := store.Save(rec, httptest.NewRequest(http.MethodGet, "/", nil), "onboarding", &WizardState{Step: "extras"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	cookie := rec.Result().Cookies()[0]
+	if !cookie.Secure {
+		t.Error("Expected a Secure wizard cookie")
+	}
+	if cookie.MaxAge != int(DefaultWizardStateTTL/time.Second) {
+		t.Errorf("Expected cookie MaxAge %d, got %d", int(DefaultWizardStateTTL/time.Second), cookie.MaxAge)
+	}
+
+	load := func() *WizardState {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(cookie)
+		state, _ := store.Load(req, "onboarding")
+		return state
+	}
+
+	store.now = func() time.Time { return issued.Add(DefaultWizardStateTTL - time.Minute) }
+	if state := load(); state == nil || state.Step != "extras" {
+		t.Errorf("Expected state within TTL, got %+v", state)
+	}
+
+	// Повторно предъявленная старая cookie отклоняется
+	store.now = func() time.Time { return issued.Add(DefaultWizardStateTTL + time.Minute) }
+	if state := load(); state != nil {
+		t.Errorf("Expected expired state to be ignored, got %+v", state)
+	}
+}
+
+func TestCookieWizardStoreShortSecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for short secret")
+		}
+	}()
+	NewCookieWizardStore([]byte("secret"))
+}
+
+func TestWizardRespond(t *testing.T) {
+	client := &wizardClient{t: t, wizard: newOnboardingWizard(NewMemoryWizardStore()), cookies: map[string]*http.Cookie{}}
+	session, _, _ := client.submit(url.Values{"onboarding_name": {"John"}, "onboarding_email": {"john@example.com"}})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := session.Respond(rec, req); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`<li aria-current="step">Account type</li>`,
+		`<li class="completed">Account</li>`,
+		`name="onboarding_company"`,
+		`value="back" formnovalidate`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected HTML to contain %q:\n%s", want, body)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/json")
+	if err := session.Respond(rec, req); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	var data WizardJSON
+	if err := json.NewDecoder(rec.Body).Decode(&data); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if data.Progress.Step != "type" || data.Progress.Index != 2 || len(data.Form.Fields) != 1 {
+		t.Errorf("Unexpected JSON: %+v", data)
+	}
+}
@@ -7,89 +7,204 @@ import (
 )
 
 const (
-	csrfTokenCookieName = "csrf_token" // Имя cookie для CSRF-токена
+	formContextKey  = "form"         // Ключ формы в контексте Echo
+	modelContextKey = "form_model"   // Ключ модели формы в контексте Echo
+	defaultTemplate = "default.html" // Шаблон формы по умолчанию
 )
 
 // FormMiddleware возвращает middleware для автоматической привязки данных.
+//
+// Deprecated: модель создается один раз и используется всеми запросами одновременно,
+// используйте ModelFormMiddleware.
 func FormMiddleware(model interface{}, method, formID string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Создаем форму
-			form := core.NewForm(model, method, formID)
+			if err := bindModelForm(c, model, method, formID); err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+// FormConfig настраивает ModelFormMiddlewareWithConfig.
+type FormConfig[T any] struct {
+	NewModel func() *T // Фабрика модели: новый экземпляр структуры для каждого запроса
+	Method   string    // Метод HTTP формы
+	FormID   string    // Идентификатор формы
+
+	Configure    func(form *core.Form)                       // Настройка формы до валидации: кастомные правила, тема, виджеты
+	Validate     bool                                        // Проверять форму в middleware
+	ValidateSafe bool                                        // Проверять форму и в запросах GET, HEAD и OPTIONS
+	Template     string                                      // Шаблон для повторного рендеринга (по умолчанию default.html)
+	ErrorHandler func(c echo.Context, form *core.Form) error // Обработчик ошибок валидации вместо стандартного
+}
+
+// ModelFormMiddleware возвращает middleware, создающее новую модель и форму для каждого запроса.
+// T — тип структуры модели, newModel возвращает указатель на новый экземпляр.
+func ModelFormMiddleware[T any](newModel func() *T, method, formID string) echo.MiddlewareFunc {
+	return ModelFormMiddlewareWithConfig(FormConfig[T]{
+		NewModel: newModel,
+		Method:   method,
+		FormID:   formID,
+	})
+}
+
+// ModelFormMiddlewareWithConfig возвращает middleware, создающее модель и форму для каждого запроса
+// и, если задано в конфигурации, проверяющее форму до вызова обработчика.
+// Запросы безопасными методами (GET, HEAD, OPTIONS) обычно только показывают форму,
+// поэтому проверяются лишь при config.ValidateSafe.
+//
+// При ошибках валидации вызывается config.ErrorHandler. Если он не задан, AJAX- и API-запросам
+// возвращается JSON со статусом 422, а остальным — форма с ошибками, отрендеренная заново.
+func ModelFormMiddlewareWithConfig[T any](config FormConfig[T]) echo.MiddlewareFunc {
+	if config.Template == "" {
+		config.Template = defaultTemplate
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			model := config.NewModel()
+			if err := bindModelForm(c, model, config.Method, config.FormID); err != nil {
+				return err
+			}
 
-			// Привязываем данные из запроса к форме
-			if err := form.Bind(c.Request()); err != nil {
-				return echo.NewHTTPError(http.StatusBadRequest, "Invalid form data")
+			form := c.Get(formContextKey).(*core.Form)
+			if config.Configure != nil {
+				config.Configure(form)
 			}
 
-			// Обновляем модель данными из формы
-			if err := core.UpdateModelFromForm(model, form); err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update model")
+			if config.Validate && (config.ValidateSafe || !isSafeMethod(c.Request().Method)) {
+				if err := form.Validate(model); err != nil {
+					if config.ErrorHandler != nil {
+						return config.ErrorHandler(c, form)
+					}
+					return handleValidationError(c, form, config.Template)
+				}
 			}
 
-			// Устанавливаем форму в контекст
-			c.Set("form", form)
 			return next(c)
 		}
 	}
 }
 
+// isSafeMethod сообщает, что метод HTTP не изменяет данные и не отправляет форму.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// handleValidationError отвечает на ошибки валидации в формате, который ожидает клиент.
+func handleValidationError(c echo.Context, form *core.Form, templateName string) error {
+	return respond(c, form, templateName)
+}
+
+// Respond отвечает данными формы в формате, выбранном core.Negotiate по заголовкам запроса:
+// HTML рендерится шаблоном по умолчанию, остальные форматы — как в core.Respond.
+// Статус ответа — 422, если в форме есть ошибки валидации, иначе 200.
+func Respond(c echo.Context, form *core.Form) error {
+	return respond(c, form, defaultTemplate)
+}
+
+// respond отвечает данными формы, рендеря HTML указанным шаблоном.
+func respond(c echo.Context, form *core.Form, templateName string) error {
+	if format := core.Negotiate(c.Request()); format != core.FormatHTML {
+		return core.RespondAs(c.Response(), c.Request(), form, format)
+	}
+	return renderForm(c, form, core.ResponseStatus(form), templateName)
+}
+
+// bindModelForm создает форму, привязывает к ней данные запроса и сохраняет форму и модель в контексте.
+func bindModelForm(c echo.Context, model interface{}, method, formID string) error {
+	// Параметры пути Echo не хранятся в запросе, поэтому передаем их через извлекатель
+	r := core.WithPathParamExtractor(c.Request(), func(_ *http.Request, name string) string {
+		return c.Param(name)
+	})
+	form, err := core.BindModel(r, model, method, formID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid form data")
+	}
+
+	// Устанавливаем форму и модель в контекст
+	c.Set(formContextKey, form)
+	c.Set(modelContextKey, model)
+	return nil
+}
+
+// GetForm возвращает форму и модель *T текущего запроса.
+// Последнее значение равно false, если middleware не был вызван или тип модели не совпадает.
+func GetForm[T any](c echo.Context) (*core.Form, *T, bool) {
+	form, ok := c.Get(formContextKey).(*core.Form)
+	if !ok {
+		return nil, nil, false
+	}
+	model, ok := c.Get(modelContextKey).(*T)
+	if !ok {
+		return form, nil, false
+	}
+	return form, model, true
+}
+
 // CSRFMiddleware возвращает middleware для проверки CSRF-токена.
 func CSRFMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Получаем CSRF-токен из cookies
-			expectedToken, err := c.Cookie(csrfTokenCookieName)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusForbidden, "CSRF token not found")
-			}
-
-			// Получаем CSRF-токен из запроса (например, из формы или заголовка)
-			receivedToken := c.FormValue("csrf_token")
-			if receivedToken == "" {
-				return echo.NewHTTPError(http.StatusForbidden, "CSRF token is required")
-			}
-
-			// Сравниваем токены
-			if !isValidCSRFToken(receivedToken, expectedToken.Value) {
-				return echo.NewHTTPError(http.StatusForbidden, "Invalid CSRF token")
+			if err := core.VerifyCSRFToken(c.Request()); err != nil {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
 			}
-
 			return next(c)
 		}
 	}
 }
 
-// isValidCSRFToken проверяет, что переданный токен совпадает с ожидаемым.
-func isValidCSRFToken(receivedToken, expectedToken string) bool {
-	return receivedToken == expectedToken
+// MethodOverrideMiddleware возвращает middleware, заменяющее метод POST-запроса на метод из поля _method
+// или заголовка X-HTTP-Method-Override, если он есть в methods (по умолчанию PUT, PATCH и DELETE).
+// Регистрируйте его через e.Pre, чтобы маршрут выбирался уже по новому методу.
+func MethodOverrideMiddleware(methods ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			r.Method = core.OverrideMethod(r, methods...)
+			return next(c)
+		}
+	}
 }
 
-// SetCSRFToken устанавливает CSRF-токен в cookies.
+// SetCSRFToken устанавливает CSRF-токен в cookies с теми же атрибутами, что и core.SetCSRFCookie.
 func SetCSRFToken(c echo.Context, token string) {
-	cookie := new(http.Cookie)
-	cookie.Name = csrfTokenCookieName
-	cookie.Value = token
-	cookie.Path = "/"
-	cookie.HttpOnly = true
-	cookie.Secure = true
-	c.SetCookie(cookie)
+	core.SetCSRFCookie(c.Response(), c.Request(), token)
 }
 
 // RenderForm рендерит форму в контексте Echo.
+// CSRF-токен берется из запроса или генерируется автоматически, cookie обновляется.
 func RenderForm(c echo.Context, form *core.Form) error {
+	return renderForm(c, form, http.StatusOK, defaultTemplate)
+}
+
+// renderForm рендерит форму указанным шаблоном с заданным статусом ответа.
+func renderForm(c echo.Context, form *core.Form, status int, templateName string) error {
+	if _, err := core.EnsureCSRFToken(c.Response(), c.Request(), form); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate CSRF token")
+	}
+	if renderer, ok := c.Echo().Renderer.(*Renderer); ok {
+		renderer.ApplyWidgetTemplates(form)
+	}
+
 	// Получаем данные для рендеринга
-	renderData := form.ToResponse()
+	renderData := form.ToHTMLResponse()
 
 	// Передаем данные в шаблон
-	return c.Render(http.StatusOK, "default.html", renderData)
+	return c.Render(status, templateName, renderData)
 }
 
 // AddCustomValidationMiddleware возвращает middleware для добавления кастомных правил валидации.
 func AddCustomValidationMiddleware(fieldName string, fn core.ValidationFunc) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			form, ok := c.Get("form").(*core.Form)
+			form, ok := c.Get(formContextKey).(*core.Form)
 			if !ok {
 				return echo.NewHTTPError(http.StatusInternalServerError, "Form not found in context")
 			}
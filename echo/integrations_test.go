@@ -9,9 +9,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 type TestForm struct {
@@ -73,6 +75,33 @@ func TestCSRFMiddleware(t *testing.T) {
 	e.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// Токен из заголовка (AJAX) принимается
+	req = httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(&http.Cookie{Name: core.CSRFCookieName, Value: "token123"})
+	req.Header.Set(core.CSRFHeaderName, "token123")
+	rec = httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Токен из скрытого поля шаблона принимается
+	formData := url.Values{
+		"form_id":              {"test_form"},
+		"test_form_csrf_token": {"token123"},
+	}
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(&http.Cookie{Name: core.CSRFCookieName, Value: "token123"})
+	rec = httptest.NewRecorder()
+	e.POST("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
 // TestRenderFormSuccess проверяет успешный рендеринг формы.
@@ -93,7 +122,7 @@ func TestRenderFormSuccess(t *testing.T) {
 	}
 
 	// Устанавливаем рендерер
-	e.Renderer = renderer
+	e.Renderer = NewRenderer(renderer)
 
 	model := TestForm{
 		Method: "POST",
@@ -116,6 +145,15 @@ func TestRenderFormSuccess(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "test_form_username")
 	assert.Contains(t, rec.Body.String(), "test_form_email")
 	assert.Contains(t, rec.Body.String(), "test_form_password")
+
+	// CSRF-токен подставляется автоматически
+	assert.NotEmpty(t, form.CSRF)
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, core.CSRFCookieName, cookies[0].Name)
+		assert.Equal(t, form.CSRF, cookies[0].Value)
+	}
+	assert.Contains(t, rec.Body.String(), `<meta name="csrf-token"`)
 }
 
 // TestAddCustomValidationMiddleware проверяет добавление кастомных правил валидации.
@@ -171,3 +209,373 @@ func TestAddCustomValidationMiddleware(t *testing.T) {
 	e.ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
+
+// TestModelFormMiddleware проверяет, что для каждого запроса создается отдельная модель.
+func TestModelFormMiddleware(t *testing.T) {
+	e := echo.New()
+	created := make(chan *TestForm, 2)
+
+	e.Use(ModelFormMiddleware(func() *TestForm {
+		model := &TestForm{}
+		created <- model
+		return model
+	}, http.MethodPost, "test_form"))
+	e.POST("/", func(c echo.Context) error {
+		form, model, ok := GetForm[TestForm](c)
+		if !ok {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		assert.Equal(t, "test_form", form.FormID)
+		return c.JSON(http.StatusOK, model)
+	})
+
+	for _, username := range []string{"first", "second"} {
+		formData := url.Values{"test_form_username": {username}}
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var responseData TestForm
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseData))
+		assert.Equal(t, username, responseData.Username)
+	}
+
+	first, second := <-created, <-created
+	assert.NotSame(t, first, second)
+	assert.Equal(t, "first", first.Username)
+	assert.Equal(t, "second", second.Username)
+}
+
+// TestGetFormWrongType проверяет, что GetForm сообщает о несовпадении типа модели.
+func TestGetFormWrongType(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	_, _, ok := GetForm[TestForm](c)
+	assert.False(t, ok)
+
+	c.Set(formContextKey, core.NewForm(&TestForm{}, http.MethodPost, "test_form"))
+	c.Set(modelContextKey, "not a model")
+	form, _, ok := GetForm[TestForm](c)
+	assert.False(t, ok)
+	assert.NotNil(t, form)
+}
+
+// TestModelFormMiddlewareValidation проверяет обработку ошибок валидации в middleware.
+func TestModelFormMiddlewareValidation(t *testing.T) {
+	e := echo.New()
+	renderer, err := core.NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+	e.Renderer = NewRenderer(renderer)
+
+	config := FormConfig[TestForm]{
+		NewModel: func() *TestForm { return &TestForm{} },
+		Method:   http.MethodPost,
+		FormID:   "test_form",
+		Validate: true,
+	}
+	handler := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	e.POST("/", handler, ModelFormMiddlewareWithConfig(config))
+
+	config.ErrorHandler = func(c echo.Context, form *core.Form) error {
+		return c.String(http.StatusTeapot, form.GetErrors()["username"])
+	}
+	e.POST("/custom", handler, ModelFormMiddlewareWithConfig(config))
+
+	invalid := url.Values{
+		"test_form_username": {"us"},
+		"test_form_email":    {"test@example.com"},
+		"test_form_password": {"password"},
+	}
+	send := func(path string, data url.Values, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(data.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// AJAX-запрос получает JSON с ошибками
+	rec := send("/", invalid, map[string]string{"X-Requested-With": "XMLHttpRequest"})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	responseData, err := core.DecodeFormJSON(rec.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "Username must be at least 3 characters", responseData.Errors["username"])
+
+	// API-клиент, ожидающий JSON, получает JSON с ошибками
+	rec = send("/", invalid, map[string]string{"Accept": "application/json"})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+
+	// Браузер получает форму с ошибками
+	rec = send("/", invalid, map[string]string{"Accept": "text/html,application/xhtml+xml"})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Username must be at least 3 characters")
+	assert.Contains(t, rec.Body.String(), `value="us"`)
+
+	// Пользовательский обработчик ошибок
+	rec = send("/custom", invalid, nil)
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "Username must be at least 3 characters", rec.Body.String())
+
+	// Корректные данные передаются обработчику
+	valid := url.Values{
+		"test_form_username": {"testuser"},
+		"test_form_email":    {"test@example.com"},
+		"test_form_password": {"password"},
+	}
+	rec = send("/", valid, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestModelFormMiddlewareConfigure проверяет, что правила из Configure учитываются при валидации в middleware.
+func TestModelFormMiddlewareConfigure(t *testing.T) {
+	e := echo.New()
+	config := FormConfig[TestForm]{
+		NewModel: func() *TestForm { return &TestForm{} },
+		Method:   http.MethodPost,
+		FormID:   "test_form",
+		Validate: true,
+		Configure: func(form *core.Form) {
+			form.AddCustomValidation("username", func(value string) error {
+				if value == "admin" {
+					return errors.New("Username is taken")
+				}
+				return nil
+			})
+		},
+		ErrorHandler: func(c echo.Context, form *core.Form) error {
+			return c.String(http.StatusUnprocessableEntity, form.GetErrors()["username"])
+		},
+	}
+	e.POST("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, ModelFormMiddlewareWithConfig(config))
+
+	data := url.Values{
+		"test_form_username": {"admin"},
+		"test_form_email":    {"test@example.com"},
+		"test_form_password": {"password"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "Username is taken", rec.Body.String())
+}
+
+// TestModelFormMiddlewareSafeMethods проверяет, что GET-запрос, показывающий форму, не проверяется без ValidateSafe.
+func TestModelFormMiddlewareSafeMethods(t *testing.T) {
+	e := echo.New()
+	config := FormConfig[TestForm]{
+		NewModel: func() *TestForm { return &TestForm{} },
+		Method:   http.MethodPost,
+		FormID:   "test_form",
+		Validate: true,
+		ErrorHandler: func(c echo.Context, form *core.Form) error {
+			return c.NoContent(http.StatusUnprocessableEntity)
+		},
+	}
+	handler := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/", handler, ModelFormMiddlewareWithConfig(config))
+
+	config.ValidateSafe = true
+	e.GET("/strict", handler, ModelFormMiddlewareWithConfig(config))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/strict", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+// TestSetCSRFToken проверяет, что cookie с токеном создается с атрибутами core.NewCSRFCookie.
+func TestSetCSRFToken(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	SetCSRFToken(e.NewContext(req, rec), "token123")
+
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, core.CSRFCookieName, cookies[0].Name)
+		assert.Equal(t, "token123", cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.True(t, cookies[0].Secure, "cookie must be Secure even without TLS on the request, e.g. behind a proxy")
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	}
+}
+
+// newBrokenDevRenderer возвращает рендерер в режиме разработки, шаблон которого после создания
+// заменен шаблоном с ошибкой разбора: прежний набор шаблонов остается в памяти.
+func newBrokenDevRenderer(t *testing.T) *core.TemplateRenderer {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "default.html")
+	start := time.Now().Add(-time.Hour)
+	if err := os.WriteFile(path, []byte(`<p>{{ .FormID }}</p>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, start, start); err != nil {
+		t.Fatal(err)
+	}
+	renderer, err := core.NewTemplateRenderer(dir, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+	renderer.DevMode = true
+
+	if err := os.WriteFile(path, []byte(`<p>{{ .FormID </p>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, start.Add(time.Minute), start.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	return renderer
+}
+
+// TestRenderFormDevModeError проверяет, что в режиме разработки ошибка шаблона показывается
+// страницей с ее описанием, а не JSON-ответом обработчика ошибок Echo.
+func TestRenderFormDevModeError(t *testing.T) {
+	e := echo.New()
+	e.Renderer = NewRenderer(newBrokenDevRenderer(t))
+	e.GET("/", func(c echo.Context) error {
+		return RenderForm(c, core.NewForm(&TestForm{}, http.MethodPost, "test_form"))
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), "<h1>Template error</h1>")
+}
+
+// TestRespond проверяет выбор формата ответа по заголовкам запроса.
+func TestRespond(t *testing.T) {
+	e := echo.New()
+	renderer, err := core.NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+	e.Renderer = NewRenderer(renderer)
+
+	form := core.NewForm(&TestForm{}, http.MethodPost, "test_form")
+	e.GET("/", func(c echo.Context) error {
+		return Respond(c, form)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAccept, "text/html")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "test_form_username")
+
+	form.AddError("username", "Username is required")
+	req = httptest.NewRequest(http.MethodGet, "/?format=json", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	assert.Contains(t, rec.Body.String(), "Username is required")
+}
+
+// TestModelFormMiddlewareJSONBody проверяет привязку и валидацию JSON-тела запроса.
+func TestModelFormMiddlewareJSONBody(t *testing.T) {
+	e := echo.New()
+	config := FormConfig[TestForm]{
+		NewModel: func() *TestForm { return &TestForm{} },
+		Method:   http.MethodPost,
+		FormID:   "test_form",
+		Validate: true,
+	}
+	e.POST("/", func(c echo.Context) error {
+		_, model, _ := GetForm[TestForm](c)
+		return c.String(http.StatusOK, model.Username)
+	}, ModelFormMiddlewareWithConfig(config))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAccept, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send(`{"username": "testuser", "email": "test@example.com", "password": "password"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "testuser", rec.Body.String())
+
+	rec = send(`{"username": "us", "email": "test@example.com", "password": "password"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	responseData, err := core.DecodeFormJSON(rec.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "Username must be at least 3 characters", responseData.Errors["username"])
+
+	rec = send(`{"username":`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestModelFormMiddlewarePathParams проверяет привязку параметров маршрута Echo.
+func TestModelFormMiddlewarePathParams(t *testing.T) {
+	type PathForm struct {
+		ID    int    `form:"id" source:"path"`
+		Query string `form:"q" source:"query"`
+	}
+
+	e := echo.New()
+	e.GET("/users/:id", func(c echo.Context) error {
+		_, model, _ := GetForm[PathForm](c)
+		return c.JSON(http.StatusOK, model)
+	}, ModelFormMiddleware(func() *PathForm { return &PathForm{} }, http.MethodGet, "search"))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42?q=golang", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var responseData PathForm
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseData))
+	assert.Equal(t, PathForm{ID: 42, Query: "golang"}, responseData)
+}
+
+func TestMethodOverrideMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Pre(MethodOverrideMiddleware(http.MethodDelete))
+	e.DELETE("/users/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, "deleted "+c.Param("id"))
+	})
+	e.POST("/users/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, "posted")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users/7", strings.NewReader(url.Values{core.MethodOverrideField: {"DELETE"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, "deleted 7", rec.Body.String())
+
+	// PUT не входит в заданный список методов
+	req = httptest.NewRequest(http.MethodPost, "/users/7", nil)
+	req.Header.Set(core.MethodOverrideHeader, http.MethodPut)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, "posted", rec.Body.String())
+}
@@ -0,0 +1,27 @@
+package echo
+
+import (
+	"github.com/DBenyukh/goform/core"
+	"github.com/labstack/echo/v4"
+	"io"
+)
+
+// Renderer адаптирует core.TemplateRenderer к интерфейсу echo.Renderer.
+type Renderer struct {
+	*core.TemplateRenderer
+}
+
+// NewRenderer создает рендерер Echo на основе рендерера шаблонов goform.
+func NewRenderer(tr *core.TemplateRenderer) *Renderer {
+	return &Renderer{TemplateRenderer: tr}
+}
+
+// Render выполняет рендеринг шаблона. В режиме разработки ошибка загрузки или выполнения
+// шаблона сразу записывается в ответ страницей с ее описанием, а не передается обработчику ошибок Echo.
+func (r *Renderer) Render(w io.Writer, name string, data interface{}, c echo.Context) error {
+	err := r.Execute(w, name, data)
+	if err != nil && r.DevMode && c != nil && !c.Response().Committed {
+		r.RenderError(c.Response(), err)
+	}
+	return err
+}
@@ -0,0 +1,125 @@
+package fiber
+
+import (
+	"github.com/DBenyukh/goform/core"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"net/http"
+)
+
+const (
+	formContextKey  = "form"         // Ключ формы в контексте Fiber
+	modelContextKey = "form_model"   // Ключ модели формы в контексте Fiber
+	defaultTemplate = "default.html" // Шаблон формы по умолчанию
+)
+
+// ModelFormMiddleware возвращает middleware, создающее новую модель и форму для каждого запроса.
+// T — тип структуры модели, newModel возвращает указатель на новый экземпляр.
+// Поля с тегом source:"path" получают параметры маршрута, поэтому для них middleware
+// подключается к маршруту (app.Get("/users/:id", middleware, handler)), а не через app.Use.
+func ModelFormMiddleware[T any](newModel func() *T, method, formID string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		r, err := adaptor.ConvertRequest(c, false)
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, "Invalid form data")
+		}
+
+		r = core.WithPathParamExtractor(r, func(_ *http.Request, name string) string {
+			return c.Params(name)
+		})
+
+		model := newModel()
+		form, err := core.BindModel(r, model, method, formID)
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, "Invalid form data")
+		}
+
+		// Устанавливаем форму и модель в контекст
+		c.Locals(formContextKey, form)
+		c.Locals(modelContextKey, model)
+		return c.Next()
+	}
+}
+
+// GetForm возвращает форму и модель *T текущего запроса.
+// Последнее значение равно false, если middleware не был вызван или тип модели не совпадает.
+func GetForm[T any](c *fiber.Ctx) (*core.Form, *T, bool) {
+	form, ok := c.Locals(formContextKey).(*core.Form)
+	if !ok {
+		return nil, nil, false
+	}
+	model, ok := c.Locals(modelContextKey).(*T)
+	if !ok {
+		return form, nil, false
+	}
+	return form, model, true
+}
+
+// CSRFMiddleware возвращает middleware для проверки CSRF-токена.
+func CSRFMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		r, err := adaptor.ConvertRequest(c, false)
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, "Invalid request")
+		}
+
+		if err := core.VerifyCSRFToken(r); err != nil {
+			return fiber.NewError(http.StatusForbidden, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+// RenderForm рендерит форму шаблоном по умолчанию через Views приложения Fiber.
+// CSRF-токен берется из запроса или генерируется автоматически, cookie обновляется.
+func RenderForm(c *fiber.Ctx, form *core.Form) error {
+	r, err := adaptor.ConvertRequest(c, false)
+	if err != nil {
+		return err
+	}
+
+	token, err := core.RequestCSRFToken(r)
+	if err != nil {
+		return fiber.NewError(http.StatusInternalServerError, "Failed to generate CSRF token")
+	}
+	setCookie(c, core.NewCSRFCookie(r, token))
+	form.AddCSRFToken(token)
+	if views, ok := c.App().Config().Views.(*Views); ok {
+		views.ApplyWidgetTemplates(form)
+	}
+
+	return c.Render(defaultTemplate, form.ToHTMLResponse())
+}
+
+// setCookie устанавливает cookie net/http в ответ Fiber.
+func setCookie(c *fiber.Ctx, cookie *http.Cookie) {
+	sameSite := fiber.CookieSameSiteLaxMode
+	switch cookie.SameSite {
+	case http.SameSiteStrictMode:
+		sameSite = fiber.CookieSameSiteStrictMode
+	case http.SameSiteNoneMode:
+		sameSite = fiber.CookieSameSiteNoneMode
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     cookie.Name,
+		Value:    cookie.Value,
+		Path:     cookie.Path,
+		HTTPOnly: cookie.HttpOnly,
+		Secure:   cookie.Secure,
+		SameSite: sameSite,
+	})
+}
+
+// AddCustomValidationMiddleware возвращает middleware для добавления кастомных правил валидации.
+func AddCustomValidationMiddleware(fieldName string, fn core.ValidationFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		form, ok := c.Locals(formContextKey).(*core.Form)
+		if !ok {
+			return fiber.NewError(http.StatusInternalServerError, "Form not found in context")
+		}
+
+		form.AddCustomValidation(fieldName, fn)
+		return c.Next()
+	}
+}
@@ -0,0 +1,151 @@
+package fiber
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DBenyukh/goform/core"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type TestForm struct {
+	Username string `form:"username" validate:"required,min=3" validate_msg:"Username must be at least 3 characters"`
+	Email    string `form:"email" validate:"required,email" validate_msg:"Please provide a valid email address"`
+	Password string `form:"password" validate:"required" validate_msg:"Password is required"`
+}
+
+func newFormRequest(data url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// TestModelFormMiddleware проверяет привязку данных формы к новой модели.
+func TestModelFormMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(ModelFormMiddleware(func() *TestForm { return &TestForm{} }, http.MethodPost, "test_form"))
+	app.Post("/", func(c *fiber.Ctx) error {
+		_, model, ok := GetForm[TestForm](c)
+		if !ok {
+			return c.SendStatus(http.StatusInternalServerError)
+		}
+		return c.JSON(model)
+	})
+
+	resp, err := app.Test(newFormRequest(url.Values{"test_form_username": {"testuser"}}))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var responseData TestForm
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseData))
+	assert.Equal(t, "testuser", responseData.Username)
+}
+
+// TestCSRFMiddleware проверяет корректность работы CSRFMiddleware.
+func TestCSRFMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(CSRFMiddleware())
+	app.Post("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	resp, err := app.Test(newFormRequest(url.Values{}))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	req := newFormRequest(url.Values{"form_id": {"test_form"}, "test_form_csrf_token": {"token123"}})
+	req.AddCookie(&http.Cookie{Name: core.CSRFCookieName, Value: "token123"})
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// TestRenderForm проверяет рендеринг формы через Views приложения Fiber.
+func TestRenderForm(t *testing.T) {
+	renderer, err := core.NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	app := fiber.New(fiber.Config{Views: NewViews(renderer)})
+	app.Get("/", func(c *fiber.Ctx) error {
+		form := core.NewForm(&TestForm{}, http.MethodPost, "test_form")
+		form.RenderHTML = true
+		return RenderForm(c, form)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: core.CSRFCookieName, Value: "token123"})
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "test_form_username")
+	assert.Contains(t, string(body), `name="test_form_csrf_token" value="token123"`)
+	if cookies := resp.Cookies(); assert.Len(t, cookies, 1) {
+		assert.Equal(t, "token123", cookies[0].Value)
+	}
+}
+
+// TestAddCustomValidationMiddleware проверяет добавление кастомных правил валидации.
+func TestAddCustomValidationMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(ModelFormMiddleware(func() *TestForm { return &TestForm{} }, http.MethodPost, "test_form"))
+	app.Use(AddCustomValidationMiddleware("username", func(value string) error {
+		if len(value) < 5 {
+			return errors.New("username too short")
+		}
+		return nil
+	}))
+	app.Post("/", func(c *fiber.Ctx) error {
+		form, model, _ := GetForm[TestForm](c)
+		if err := form.Validate(model); err != nil {
+			return c.SendStatus(http.StatusBadRequest)
+		}
+		return c.SendStatus(http.StatusOK)
+	})
+
+	formData := url.Values{
+		"test_form_username": {"user"},
+		"test_form_email":    {"test@example.com"},
+		"test_form_password": {"password"},
+	}
+	resp, err := app.Test(newFormRequest(formData))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	formData.Set("test_form_username", "longusername")
+	resp, err = app.Test(newFormRequest(formData))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// TestModelFormMiddlewarePathParams проверяет привязку параметров маршрута Fiber.
+func TestModelFormMiddlewarePathParams(t *testing.T) {
+	type PathForm struct {
+		ID    int    `form:"id" source:"path"`
+		Query string `form:"q" source:"query"`
+	}
+
+	app := fiber.New()
+	app.Get("/users/:id", ModelFormMiddleware(func() *PathForm { return &PathForm{} }, http.MethodGet, "search"), func(c *fiber.Ctx) error {
+		_, model, _ := GetForm[PathForm](c)
+		return c.JSON(model)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/42?q=golang", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var responseData PathForm
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseData))
+	assert.Equal(t, PathForm{ID: 42, Query: "golang"}, responseData)
+}
@@ -0,0 +1,27 @@
+package fiber
+
+import (
+	"github.com/DBenyukh/goform/core"
+	"io"
+)
+
+// Views адаптирует core.TemplateRenderer к интерфейсу fiber.Views.
+type Views struct {
+	*core.TemplateRenderer
+}
+
+// NewViews создает Views для fiber.Config на основе рендерера шаблонов goform.
+func NewViews(tr *core.TemplateRenderer) *Views {
+	return &Views{TemplateRenderer: tr}
+}
+
+// Load ничего не делает: шаблоны уже загружены в NewTemplateRenderer.
+func (v *Views) Load() error {
+	return nil
+}
+
+// Render выполняет рендеринг шаблона. Макеты Fiber не поддерживаются:
+// используйте макеты goform из каталога layouts.
+func (v *Views) Render(w io.Writer, name string, data interface{}, layouts ...string) error {
+	return v.Execute(w, name, data)
+}
@@ -0,0 +1,544 @@
+// Package gen генерирует код форм без рефлексии по структурам с тегами form.
+// Используется командой goform gen:
+//
+//	//go:generate go run github.com/DBenyukh/goform/cmd/goform gen -type Signup
+//
+// Для типа Signup генерируется тип SignupForm с методами Bind, UpdateModel и Validate,
+// которые ведут себя так же, как core.NewForm, core.UpdateModelFromForm и Form.Validate,
+// но не разбирают модель через рефлексию на каждом запросе. Ошибки в тегах (неизвестные
+// правила, некорректные регулярные выражения, неподдерживаемые типы) обнаруживаются при генерации.
+//
+// Экспортируемое поле без тега form, как и в core.NewForm, становится скрытым полем формы.
+// В отличие от рефлексии, генератор не принимает неэкспортируемые поля и правила валидации
+// у полей без тега form: такие поля нужно пометить form:"-".
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/DBenyukh/goform/core"
+)
+
+// kinds сопоставляет поддерживаемые типы полей модели с reflect.Kind и размером в битах для strconv.
+var kinds = map[string]struct {
+	kind string
+	bits string
+}{
+	"string":  {"String", ""},
+	"bool":    {"Bool", ""},
+	"int":     {"Int", "strconv.IntSize"},
+	"int8":    {"Int8", "8"},
+	"int16":   {"Int16", "16"},
+	"int32":   {"Int32", "32"},
+	"rune":    {"Int32", "32"},
+	"int64":   {"Int64", "64"},
+	"uint":    {"Uint", "strconv.IntSize"},
+	"uint8":   {"Uint8", "8"},
+	"byte":    {"Uint8", "8"},
+	"uint16":  {"Uint16", "16"},
+	"uint32":  {"Uint32", "32"},
+	"uint64":  {"Uint64", "64"},
+	"float32": {"Float32", "32"},
+	"float64": {"Float64", "64"},
+}
+
+// formType описывает форму, генерируемую для типа модели.
+type formType struct {
+	Type   string // Имя типа модели
+	Name   string // Имя сгенерированного типа формы
+	Fields []*formField
+}
+
+// formField описывает поле модели, участвующее в форме.
+type formField struct {
+	GoName  string
+	GoType  string
+	Name    string // Имя поля формы из тега form
+	Hidden  bool   // Поле без тега form скрыто и не имеет имени, как в core.NewForm
+	Kind    string // Имя константы reflect.Kind
+	Bits    string // Размер в битах для strconv
+	Label   string
+	Rules   []rule
+	Message string
+	Choices []core.Choice
+	Default string
+	Pattern string
+	Source  string
+	Widget  string
+
+	PatternVar string // Имя переменной со скомпилированным регулярным выражением
+}
+
+// rule — правило валидации с сообщением, подготовленным при генерации.
+type rule struct {
+	Name    string
+	Param   int
+	Message string
+}
+
+// Generate разбирает Go-файлы пакета в каталоге dir и возвращает отформатированный исходный код
+// форм для перечисленных типов.
+func Generate(dir string, types ...string) ([]byte, error) {
+	if len(types) == 0 {
+		return nil, fmt.Errorf("no types to generate")
+	}
+	pkg, specs, err := parsePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Package string
+		Forms   []*formType
+		Imports []string
+	}{Package: pkg}
+	imports := map[string]bool{"errors": true, "fmt": true, "net/http": true, "reflect": true}
+	for _, name := range types {
+		spec, ok := specs[name]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in %s", name, dir)
+		}
+		form, err := parseForm(name, spec)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range form.Fields {
+			if field.Bits != "" {
+				imports["strconv"] = true
+			}
+			if field.Pattern != "" {
+				imports["regexp"] = true
+			}
+			for _, r := range field.Rules {
+				if r.Name == "email" {
+					imports["strings"] = true
+				}
+			}
+		}
+		data.Forms = append(data.Forms, form)
+	}
+	for path := range imports {
+		data.Imports = append(data.Imports, path)
+	}
+	sort.Strings(data.Imports)
+
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// OutputName возвращает имя файла по умолчанию для сгенерированного кода типа.
+func OutputName(typeName string) string {
+	return strings.ToLower(typeName) + "_goform.go"
+}
+
+// parsePackage разбирает Go-файлы каталога (кроме тестов) и возвращает имя пакета и структуры по именам.
+func parsePackage(dir string) (string, map[string]*ast.StructType, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pkg string
+	specs := make(map[string]*ast.StructType)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		pkg = file.Name.Name
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					specs[spec.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+	if pkg == "" {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, specs, nil
+}
+
+// parseForm проверяет теги структуры и собирает описание формы.
+func parseForm(typeName string, st *ast.StructType) (*formType, error) {
+	form := &formType{Type: typeName, Name: typeName + "Form"}
+	seen := make(map[string]bool)
+
+	for _, astField := range st.Fields.List {
+		var tag reflect.StructTag
+		if astField.Tag != nil {
+			value, err := strconv.Unquote(astField.Tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid struct tag: %w", typeName, err)
+			}
+			tag = reflect.StructTag(value)
+		}
+		if len(astField.Names) == 0 {
+			return nil, fmt.Errorf("%s: embedded fields are not supported", typeName)
+		}
+
+		for _, ident := range astField.Names {
+			field, err := parseField(ident.Name, astField.Type, tag)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", typeName, ident.Name, err)
+			}
+			if field == nil {
+				continue
+			}
+			if seen[field.Name] && !field.Hidden {
+				return nil, fmt.Errorf("%s.%s: duplicate form field %q", typeName, ident.Name, field.Name)
+			}
+			seen[field.Name] = true
+			if field.Pattern != "" {
+				field.PatternVar = lowerFirst(typeName) + field.GoName + "Pattern"
+			}
+			form.Fields = append(form.Fields, field)
+		}
+	}
+	if len(form.Fields) == 0 {
+		return nil, fmt.Errorf("%s: no fields with a form tag", typeName)
+	}
+	return form, nil
+}
+
+// parseField проверяет теги поля модели. Для полей с тегом form:"-" возвращает nil.
+// Поле без тега form становится скрытым, как в core.NewForm.
+func parseField(goName string, expr ast.Expr, tag reflect.StructTag) (*formField, error) {
+	name := tag.Get("form")
+	hidden := name == ""
+	switch {
+	case name == "-":
+		return nil, nil
+	case hidden && !ast.IsExported(goName):
+		return nil, fmt.Errorf(`unexported field without a form tag; use form:"-" to skip the field`)
+	case !ast.IsExported(goName):
+		return nil, fmt.Errorf("unexported fields cannot be bound")
+	case hidden && (tag.Get("validate") != "" || tag.Get("pattern") != ""):
+		return nil, fmt.Errorf("validation rules require a form tag")
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %s", typeString(expr))
+	}
+	kind, ok := kinds[ident.Name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %s", ident.Name)
+	}
+
+	field := &formField{
+		GoName:  goName,
+		GoType:  ident.Name,
+		Name:    name,
+		Hidden:  hidden,
+		Kind:    kind.kind,
+		Bits:    kind.bits,
+		Label:   tag.Get("label"),
+		Message: tag.Get("validate_msg"),
+		Pattern: tag.Get("pattern"),
+		Source:  tag.Get("source"),
+		Widget:  tag.Get("widget"),
+	}
+	if choices := tag.Get("choices"); choices != "" {
+		field.Choices = core.ParseChoices(choices)
+	}
+	if validate := tag.Get("validate"); validate != "" {
+		for _, item := range strings.Split(validate, ",") {
+			r, err := parseRule(item, field.Message)
+			if err != nil {
+				return nil, err
+			}
+			field.Rules = append(field.Rules, r)
+		}
+	}
+	if def, ok := tag.Lookup("default"); ok {
+		if err := checkDefault(field, def); err != nil {
+			return nil, err
+		}
+		field.Default = def
+	}
+	if field.Pattern != "" {
+		if _, err := regexp.Compile("^(?:" + field.Pattern + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	if source, _, _ := strings.Cut(field.Source, ":"); source != "" {
+		switch source {
+		case core.SourceForm, core.SourceQuery, core.SourcePath, core.SourceHeader, core.SourceCookie:
+		default:
+			return nil, fmt.Errorf("unknown source %q", source)
+		}
+	}
+	return field, nil
+}
+
+// parseRule разбирает правило из тега validate и готовит сообщение об ошибке, как Form.Validate.
+func parseRule(item, message string) (rule, error) {
+	name, param, _ := strings.Cut(item, "=")
+	switch name {
+	case "required", "email":
+		if param != "" {
+			return rule{}, fmt.Errorf("rule %q takes no parameter", item)
+		}
+		return rule{Name: name, Message: message}, nil
+	case "min", "max":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return rule{}, fmt.Errorf("rule %q: expected a number", item)
+		}
+		if strings.Contains(message, "%") {
+			message = fmt.Sprintf(message, n)
+		}
+		return rule{Name: name, Param: n, Message: message}, nil
+	default:
+		return rule{}, fmt.Errorf("unknown rule %q", item)
+	}
+}
+
+// checkDefault проверяет, что значение тега default можно записать в поле модели.
+func checkDefault(field *formField, value string) error {
+	var err error
+	switch {
+	case field.Kind == "String", field.Kind == "Bool":
+	case strings.HasPrefix(field.Kind, "Int"):
+		_, err = strconv.ParseInt(value, 10, 64)
+	case strings.HasPrefix(field.Kind, "Uint"):
+		_, err = strconv.ParseUint(value, 10, 64)
+	default:
+		_, err = strconv.ParseFloat(value, 64)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid default %q: %w", value, err)
+	}
+	return nil
+}
+
+// typeString возвращает запись типа для сообщений об ошибках.
+func typeString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + typeString(e.X)
+	case *ast.SelectorExpr:
+		return typeString(e.X) + "." + e.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + typeString(e.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(e.Key) + "]" + typeString(e.Value)
+	case *ast.Ident:
+		return e.Name
+	default:
+		return fmt.Sprintf("%T", expr)
+	}
+}
+
+// lowerFirst переводит первую букву имени в нижний регистр.
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+var codeTemplate = template.Must(template.New("code").Parse(`// Code generated by goform gen; DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+
+	"github.com/DBenyukh/goform/core"
+)
+{{ range $form := .Forms }}
+{{- range .Fields }}{{ if .PatternVar }}
+var {{ .PatternVar }} = regexp.MustCompile({{ printf "%q" (printf "^(?:%s)$" .Pattern) }})
+{{ end }}{{ end }}
+// {{ .Name }} — форма модели {{ .Type }}, сгенерированная без рефлексии.
+type {{ .Name }} struct {
+	*core.Form
+	Model *{{ .Type }}
+}
+
+// New{{ .Name }} создает форму для модели, как core.NewForm.
+func New{{ .Name }}(model *{{ .Type }}, method, formID string) *{{ .Name }} {
+	var fields []*core.Field
+	var field *core.Field
+{{ range .Fields }}
+	field = core.NewModelField({{ printf "%q" .Name }}, reflect.{{ .Kind }})
+{{- if .Hidden }}
+	field.Hidden = true
+{{- end }}
+{{- with .Label }}
+	field.Label = {{ printf "%q" . }}
+{{- end }}
+{{- with .Rules }}
+	field.Rules = []string{ {{- range $i, $r := . }}{{ if $i }}, {{ end }}{{ if eq $r.Name "min" "max" }}"{{ $r.Name }}={{ $r.Param }}"{{ else }}"{{ $r.Name }}"{{ end }}{{ end -}} }
+{{- end }}
+{{- with .Message }}
+	field.Message = {{ printf "%q" . }}
+{{- end }}
+{{- with .Choices }}
+	field.Choices = []core.Choice{
+{{- range . }}
+		{Value: {{ printf "%q" .Value }}, Label: {{ printf "%q" .Label }}},
+{{- end }}
+	}
+	field.WidgetName = "select"
+{{- end }}
+{{- with .Pattern }}
+	field.Pattern = {{ printf "%q" . }}
+{{- end }}
+{{- with .Source }}
+	field.Source = {{ printf "%q" . }}
+{{- end }}
+{{- with .Widget }}
+	field.WidgetName = {{ printf "%q" . }}
+{{- end }}
+{{- with .Default }}
+	field.Default = {{ printf "%q" . }}
+{{- end }}
+{{- if eq .Kind "String" }}
+	field.Value = ""
+	if model.{{ .GoName }} != "" {
+		field.Value = model.{{ .GoName }}
+	}
+{{- else if eq .Kind "Bool" }}
+	field.Value = ""
+	if model.{{ .GoName }} {
+		field.Value = "true"
+	}
+{{- else if eq .Kind "Float32" "Float64" }}
+	field.Value = strconv.FormatFloat(float64(model.{{ .GoName }}), 'f', -1, {{ .Bits }})
+{{- else if eq .Kind "Uint" "Uint8" "Uint16" "Uint32" "Uint64" }}
+	field.Value = strconv.FormatUint(uint64(model.{{ .GoName }}), 10)
+{{- else }}
+	field.Value = strconv.FormatInt(int64(model.{{ .GoName }}), 10)
+{{- end }}
+	fields = append(fields, field)
+{{ end }}
+	return &{{ .Name }}{Form: core.NewFormFromFields(method, formID, fields...), Model: model}
+}
+
+// Bind привязывает данные из запроса к форме и обновляет модель.
+func (f *{{ .Name }}) Bind(r *http.Request) error {
+	if err := f.Form.Bind(r); err != nil {
+		return err
+	}
+	return f.UpdateModel()
+}
+
+// UpdateModel записывает значения полей формы в модель, как core.UpdateModelFromForm.
+func (f *{{ .Name }}) UpdateModel() error {
+	model := f.Model
+{{- range .Fields }}
+	if field := f.FieldByName({{ printf "%q" .Name }}); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", {{ printf "%q" .Name }})
+		}
+		value := field.StringValue()
+{{- if eq .Kind "String" }}
+		model.{{ .GoName }} = value
+{{- else if eq .Kind "Bool" }}
+		model.{{ .GoName }} = value == "on" || value == "true" || value == "1"
+{{- else }}
+		if value == "" {
+			model.{{ .GoName }} = 0
+		} else {
+{{- if eq .Kind "Float32" "Float64" }}
+			n, err := strconv.ParseFloat(value, {{ .Bits }})
+{{- else if eq .Kind "Uint" "Uint8" "Uint16" "Uint32" "Uint64" }}
+			n, err := strconv.ParseUint(value, 10, {{ .Bits }})
+{{- else }}
+			n, err := strconv.ParseInt(value, 10, {{ .Bits }})
+{{- end }}
+			if err != nil {
+				return fmt.Errorf("field %s: %w", {{ printf "%q" .Name }}, err)
+			}
+			model.{{ .GoName }} = {{ .GoType }}(n)
+		}
+{{- end }}
+	}
+{{- end }}
+	return nil
+}
+
+// Validate проверяет данные формы по правилам из тегов модели, как Form.Validate.
+func (f *{{ .Name }}) Validate() error {
+	for _, field := range f.Fields {
+		value := field.StringValue()
+		if field.CustomValidation != nil {
+			if err := field.CustomValidation(value); err != nil {
+				field.Error = err.Error()
+				f.Errs[field.Name] = field.Error
+				continue
+			}
+		}
+
+		switch field.Name {
+{{- range .Fields }}{{ if or .Rules .Pattern }}
+		case {{ printf "%q" .Name }}:
+{{- range .Rules }}
+{{- if eq .Name "required" }}
+			if value == "" {
+{{- else if eq .Name "min" }}
+			if len(value) < {{ .Param }} {
+{{- else if eq .Name "max" }}
+			if len(value) > {{ .Param }} {
+{{- else }}
+			if !strings.Contains(value, "@") {
+{{- end }}
+				field.Error = {{ printf "%q" .Message }}
+				f.Errs[field.Name] = field.Error
+			}
+{{- end }}
+{{- if .PatternVar }}
+			if value != "" && !{{ .PatternVar }}.MatchString(value) {
+				field.Error = {{ printf "%q" .Message }}
+				f.Errs[field.Name] = field.Error
+			}
+{{- end }}
+{{- end }}{{ end }}
+		}
+	}
+
+	if len(f.Errs) > 0 {
+		return errors.New("validation errors")
+	}
+	return nil
+}
+{{ range .Fields }}{{ if not .Hidden }}
+// {{ .GoName }}Field возвращает поле формы {{ .Name }}.
+func (f *{{ $form.Name }}) {{ .GoName }}Field() *core.Field {
+	return f.FieldByName({{ printf "%q" .Name }})
+}
+{{ end }}{{ end }}
+{{- end }}`))
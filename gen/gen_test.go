@@ -0,0 +1,61 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateExampleUpToDate(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	src, err := Generate(dir, "Signup", "Settings")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(dir, OutputName("Signup")))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if string(src) != string(want) {
+		t.Error("Generated code is out of date, run go generate ./gen/internal/example")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"Name string `form:\"name\" validate:\"required,unique\"`", `unknown rule "unique"`},
+		{"Name string `form:\"name\" validate:\"required, min=3\"`", `unknown rule " min=3"`},
+		{"Name string `form:\"name\" validate:\"min=three\"`", `rule "min=three": expected a number`},
+		{"Name string `form:\"name\" pattern:\"[a-z\"`", "invalid pattern"},
+		{"Name string `form:\"name\" source:\"session\"`", `unknown source "session"`},
+		{"Age int `form:\"age\" default:\"old\"`", `invalid default "old"`},
+		{"name string", "unexported field without a form tag"},
+		{"Name string `validate:\"required\"`", "validation rules require a form tag"},
+		{"name string `form:\"name\"`", "unexported fields cannot be bound"},
+		{"Tags []string `form:\"tags\"`", "unsupported type []string"},
+		{"Name string `form:\"name\"`\n\tTitle string `form:\"name\"`", `duplicate form field "name"`},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		src := "package models\n\ntype Model struct {\n\t" + tt.field + "\n}\n"
+		if err := os.WriteFile(filepath.Join(dir, "model.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := Generate(dir, "Model")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.field, tt.want, err)
+		}
+	}
+}
+
+func TestGenerateUnknownType(t *testing.T) {
+	if _, err := Generate(filepath.Join("internal", "example"), "Missing"); err == nil || !strings.Contains(err.Error(), "type Missing not found") {
+		t.Errorf("Expected type not found error, got %v", err)
+	}
+}
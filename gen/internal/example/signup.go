@@ -0,0 +1,28 @@
+// Package example содержит модель, для которой goform gen генерирует код;
+// тесты пакета сравнивают сгенерированный код с формами на рефлексии.
+package example
+
+//go:generate go run github.com/DBenyukh/goform/cmd/goform gen -type Signup,Settings
+
+// Signup — модель с полями всех поддерживаемых видов.
+type Signup struct {
+	Username string  `form:"username" label:"Username" validate:"required,min=3,max=20" validate_msg:"Username must be between 3 and 20 characters, got limit %d"`
+	Email    string  `form:"email" validate:"required,email" validate_msg:"Please provide a valid email address"`
+	Age      int     `form:"age" default:"18"`
+	Score    float64 `form:"score"`
+	Level    uint8   `form:"level"`
+	Plan     string  `form:"plan" choices:"free:Free,pro:Pro" default:"free"`
+	Code     string  `form:"code" pattern:"[A-Z]{3}" validate_msg:"Code must be three capital letters"`
+	Bio      string  `form:"bio" widget:"textarea" validate:"max=200"`
+	Ref      string  `form:"ref" source:"query"`
+	Terms    bool    `form:"terms" validate:"required" validate_msg:"You must accept the terms"`
+	Internal string  `form:"-"`
+	Token    string  // Без тега form: скрытое поле, как в core.NewForm
+}
+
+// Settings — вторая модель в том же файле сгенерированного кода.
+type Settings struct {
+	Theme  string  `form:"theme" choices:"light,dark"`
+	Volume float32 `form:"volume"`
+	Limit  int64   `form:"limit" validate:"max=5"`
+}
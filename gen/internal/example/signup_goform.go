@@ -0,0 +1,485 @@
+// Code generated by goform gen; DO NOT EDIT.
+
+package example
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DBenyukh/goform/core"
+)
+
+var signupCodePattern = regexp.MustCompile("^(?:[A-Z]{3})$")
+
+// SignupForm — форма модели Signup, сгенерированная без рефлексии.
+type SignupForm struct {
+	*core.Form
+	Model *Signup
+}
+
+// NewSignupForm создает форму для модели, как core.NewForm.
+func NewSignupForm(model *Signup, method, formID string) *SignupForm {
+	var fields []*core.Field
+	var field *core.Field
+
+	field = core.NewModelField("username", reflect.String)
+	field.Label = "Username"
+	field.Rules = []string{"required", "min=3", "max=20"}
+	field.Message = "Username must be between 3 and 20 characters, got limit %d"
+	field.Value = ""
+	if model.Username != "" {
+		field.Value = model.Username
+	}
+	fields = append(fields, field)
+
+	field = core.NewModelField("email", reflect.String)
+	field.Rules = []string{"required", "email"}
+	field.Message = "Please provide a valid email address"
+	field.Value = ""
+	if model.Email != "" {
+		field.Value = model.Email
+	}
+	fields = append(fields, field)
+
+	field = core.NewModelField("age", reflect.Int)
+	field.Default = "18"
+	field.Value = strconv.FormatInt(int64(model.Age), 10)
+	fields = append(fields, field)
+
+	field = core.NewModelField("score", reflect.Float64)
+	field.Value = strconv.FormatFloat(float64(model.Score), 'f', -1, 64)
+	fields = append(fields, field)
+
+	field = core.NewModelField("level", reflect.Uint8)
+	field.Value = strconv.FormatUint(uint64(model.Level), 10)
+	fields = append(fields, field)
+
+	field = core.NewModelField("plan", reflect.String)
+	field.Choices = []core.Choice{
+		{Value: "free", Label: "Free"},
+		{Value: "pro", Label: "Pro"},
+	}
+	field.WidgetName = "select"
+	field.Default = "free"
+	field.Value = ""
+	if model.Plan != "" {
+		field.Value = model.Plan
+	}
+	fields = append(fields, field)
+
+	field = core.NewModelField("code", reflect.String)
+	field.Message = "Code must be three capital letters"
+	field.Pattern = "[A-Z]{3}"
+	field.Value = ""
+	if model.Code != "" {
+		field.Value = model.Code
+	}
+	fields = append(fields, field)
+
+	field = core.NewModelField("bio", reflect.String)
+	field.Rules = []string{"max=200"}
+	field.WidgetName = "textarea"
+	field.Value = ""
+	if model.Bio != "" {
+		field.Value = model.Bio
+	}
+	fields = append(fields, field)
+
+	field = core.NewModelField("ref", reflect.String)
+	field.Source = "query"
+	field.Value = ""
+	if model.Ref != "" {
+		field.Value = model.Ref
+	}
+	fields = append(fields, field)
+
+	field = core.NewModelField("terms", reflect.Bool)
+	field.Rules = []string{"required"}
+	field.Message = "You must accept the terms"
+	field.Value = ""
+	if model.Terms {
+		field.Value = "true"
+	}
+	fields = append(fields, field)
+
+	field = core.NewModelField("", reflect.String)
+	field.Hidden = true
+	field.Value = ""
+	if model.Token != "" {
+		field.Value = model.Token
+	}
+	fields = append(fields, field)
+
+	return &SignupForm{Form: core.NewFormFromFields(method, formID, fields...), Model: model}
+}
+
+// Bind привязывает данные из запроса к форме и обновляет модель.
+func (f *SignupForm) Bind(r *http.Request) error {
+	if err := f.Form.Bind(r); err != nil {
+		return err
+	}
+	return f.UpdateModel()
+}
+
+// UpdateModel записывает значения полей формы в модель, как core.UpdateModelFromForm.
+func (f *SignupForm) UpdateModel() error {
+	model := f.Model
+	if field := f.FieldByName("username"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "username")
+		}
+		value := field.StringValue()
+		model.Username = value
+	}
+	if field := f.FieldByName("email"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "email")
+		}
+		value := field.StringValue()
+		model.Email = value
+	}
+	if field := f.FieldByName("age"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "age")
+		}
+		value := field.StringValue()
+		if value == "" {
+			model.Age = 0
+		} else {
+			n, err := strconv.ParseInt(value, 10, strconv.IntSize)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", "age", err)
+			}
+			model.Age = int(n)
+		}
+	}
+	if field := f.FieldByName("score"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "score")
+		}
+		value := field.StringValue()
+		if value == "" {
+			model.Score = 0
+		} else {
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", "score", err)
+			}
+			model.Score = float64(n)
+		}
+	}
+	if field := f.FieldByName("level"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "level")
+		}
+		value := field.StringValue()
+		if value == "" {
+			model.Level = 0
+		} else {
+			n, err := strconv.ParseUint(value, 10, 8)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", "level", err)
+			}
+			model.Level = uint8(n)
+		}
+	}
+	if field := f.FieldByName("plan"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "plan")
+		}
+		value := field.StringValue()
+		model.Plan = value
+	}
+	if field := f.FieldByName("code"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "code")
+		}
+		value := field.StringValue()
+		model.Code = value
+	}
+	if field := f.FieldByName("bio"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "bio")
+		}
+		value := field.StringValue()
+		model.Bio = value
+	}
+	if field := f.FieldByName("ref"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "ref")
+		}
+		value := field.StringValue()
+		model.Ref = value
+	}
+	if field := f.FieldByName("terms"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "terms")
+		}
+		value := field.StringValue()
+		model.Terms = value == "on" || value == "true" || value == "1"
+	}
+	if field := f.FieldByName(""); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "")
+		}
+		value := field.StringValue()
+		model.Token = value
+	}
+	return nil
+}
+
+// Validate проверяет данные формы по правилам из тегов модели, как Form.Validate.
+func (f *SignupForm) Validate() error {
+	for _, field := range f.Fields {
+		value := field.StringValue()
+		if field.CustomValidation != nil {
+			if err := field.CustomValidation(value); err != nil {
+				field.Error = err.Error()
+				f.Errs[field.Name] = field.Error
+				continue
+			}
+		}
+
+		switch field.Name {
+		case "username":
+			if value == "" {
+				field.Error = "Username must be between 3 and 20 characters, got limit %d"
+				f.Errs[field.Name] = field.Error
+			}
+			if len(value) < 3 {
+				field.Error = "Username must be between 3 and 20 characters, got limit 3"
+				f.Errs[field.Name] = field.Error
+			}
+			if len(value) > 20 {
+				field.Error = "Username must be between 3 and 20 characters, got limit 20"
+				f.Errs[field.Name] = field.Error
+			}
+		case "email":
+			if value == "" {
+				field.Error = "Please provide a valid email address"
+				f.Errs[field.Name] = field.Error
+			}
+			if !strings.Contains(value, "@") {
+				field.Error = "Please provide a valid email address"
+				f.Errs[field.Name] = field.Error
+			}
+		case "code":
+			if value != "" && !signupCodePattern.MatchString(value) {
+				field.Error = "Code must be three capital letters"
+				f.Errs[field.Name] = field.Error
+			}
+		case "bio":
+			if len(value) > 200 {
+				field.Error = ""
+				f.Errs[field.Name] = field.Error
+			}
+		case "terms":
+			if value == "" {
+				field.Error = "You must accept the terms"
+				f.Errs[field.Name] = field.Error
+			}
+		}
+	}
+
+	if len(f.Errs) > 0 {
+		return errors.New("validation errors")
+	}
+	return nil
+}
+
+// UsernameField возвращает поле формы username.
+func (f *SignupForm) UsernameField() *core.Field {
+	return f.FieldByName("username")
+}
+
+// EmailField возвращает поле формы email.
+func (f *SignupForm) EmailField() *core.Field {
+	return f.FieldByName("email")
+}
+
+// AgeField возвращает поле формы age.
+func (f *SignupForm) AgeField() *core.Field {
+	return f.FieldByName("age")
+}
+
+// ScoreField возвращает поле формы score.
+func (f *SignupForm) ScoreField() *core.Field {
+	return f.FieldByName("score")
+}
+
+// LevelField возвращает поле формы level.
+func (f *SignupForm) LevelField() *core.Field {
+	return f.FieldByName("level")
+}
+
+// PlanField возвращает поле формы plan.
+func (f *SignupForm) PlanField() *core.Field {
+	return f.FieldByName("plan")
+}
+
+// CodeField возвращает поле формы code.
+func (f *SignupForm) CodeField() *core.Field {
+	return f.FieldByName("code")
+}
+
+// BioField возвращает поле формы bio.
+func (f *SignupForm) BioField() *core.Field {
+	return f.FieldByName("bio")
+}
+
+// RefField возвращает поле формы ref.
+func (f *SignupForm) RefField() *core.Field {
+	return f.FieldByName("ref")
+}
+
+// TermsField возвращает поле формы terms.
+func (f *SignupForm) TermsField() *core.Field {
+	return f.FieldByName("terms")
+}
+
+// SettingsForm — форма модели Settings, сгенерированная без рефлексии.
+type SettingsForm struct {
+	*core.Form
+	Model *Settings
+}
+
+// NewSettingsForm создает форму для модели, как core.NewForm.
+func NewSettingsForm(model *Settings, method, formID string) *SettingsForm {
+	var fields []*core.Field
+	var field *core.Field
+
+	field = core.NewModelField("theme", reflect.String)
+	field.Choices = []core.Choice{
+		{Value: "light", Label: "light"},
+		{Value: "dark", Label: "dark"},
+	}
+	field.WidgetName = "select"
+	field.Value = ""
+	if model.Theme != "" {
+		field.Value = model.Theme
+	}
+	fields = append(fields, field)
+
+	field = core.NewModelField("volume", reflect.Float32)
+	field.Value = strconv.FormatFloat(float64(model.Volume), 'f', -1, 32)
+	fields = append(fields, field)
+
+	field = core.NewModelField("limit", reflect.Int64)
+	field.Rules = []string{"max=5"}
+	field.Value = strconv.FormatInt(int64(model.Limit), 10)
+	fields = append(fields, field)
+
+	return &SettingsForm{Form: core.NewFormFromFields(method, formID, fields...), Model: model}
+}
+
+// Bind привязывает данные из запроса к форме и обновляет модель.
+func (f *SettingsForm) Bind(r *http.Request) error {
+	if err := f.Form.Bind(r); err != nil {
+		return err
+	}
+	return f.UpdateModel()
+}
+
+// UpdateModel записывает значения полей формы в модель, как core.UpdateModelFromForm.
+func (f *SettingsForm) UpdateModel() error {
+	model := f.Model
+	if field := f.FieldByName("theme"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "theme")
+		}
+		value := field.StringValue()
+		model.Theme = value
+	}
+	if field := f.FieldByName("volume"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "volume")
+		}
+		value := field.StringValue()
+		if value == "" {
+			model.Volume = 0
+		} else {
+			n, err := strconv.ParseFloat(value, 32)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", "volume", err)
+			}
+			model.Volume = float32(n)
+		}
+	}
+	if field := f.FieldByName("limit"); field != nil {
+		switch field.Value.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field %s: unexpected nested value", "limit")
+		}
+		value := field.StringValue()
+		if value == "" {
+			model.Limit = 0
+		} else {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", "limit", err)
+			}
+			model.Limit = int64(n)
+		}
+	}
+	return nil
+}
+
+// Validate проверяет данные формы по правилам из тегов модели, как Form.Validate.
+func (f *SettingsForm) Validate() error {
+	for _, field := range f.Fields {
+		value := field.StringValue()
+		if field.CustomValidation != nil {
+			if err := field.CustomValidation(value); err != nil {
+				field.Error = err.Error()
+				f.Errs[field.Name] = field.Error
+				continue
+			}
+		}
+
+		switch field.Name {
+		case "limit":
+			if len(value) > 5 {
+				field.Error = ""
+				f.Errs[field.Name] = field.Error
+			}
+		}
+	}
+
+	if len(f.Errs) > 0 {
+		return errors.New("validation errors")
+	}
+	return nil
+}
+
+// ThemeField возвращает поле формы theme.
+func (f *SettingsForm) ThemeField() *core.Field {
+	return f.FieldByName("theme")
+}
+
+// VolumeField возвращает поле формы volume.
+func (f *SettingsForm) VolumeField() *core.Field {
+	return f.FieldByName("volume")
+}
+
+// LimitField возвращает поле формы limit.
+func (f *SettingsForm) LimitField() *core.Field {
+	return f.FieldByName("limit")
+}
@@ -0,0 +1,173 @@
+package example
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DBenyukh/goform/core"
+)
+
+// bindReflective проходит путь на рефлексии: core.NewForm, Bind, UpdateModelFromForm и Validate.
+func bindReflective(model interface{}, r *http.Request) (*core.Form, error) {
+	form := core.NewForm(model, http.MethodPost, "signup")
+	form.AddCustomValidation("username", rejectAdmin)
+	if err := form.Bind(r); err != nil {
+		return form, err
+	}
+	if err := core.UpdateModelFromForm(model, form); err != nil {
+		return form, err
+	}
+	return form, form.Validate(model)
+}
+
+// bindGenerated проходит тот же путь через сгенерированную форму.
+func bindGenerated(model *Signup, r *http.Request) (*core.Form, error) {
+	form := NewSignupForm(model, http.MethodPost, "signup")
+	form.AddCustomValidation("username", rejectAdmin)
+	if err := form.Bind(r); err != nil {
+		return form.Form, err
+	}
+	return form.Form, form.Validate()
+}
+
+func rejectAdmin(value string) error {
+	if value == "admin" {
+		return errors.New("Username is reserved")
+	}
+	return nil
+}
+
+// assertSameForms проверяет, что формы совпадают в HTML, JSON, JSON Schema и ошибках.
+func assertSameForms(t *testing.T, want, got *core.Form) {
+	t.Helper()
+	if !reflect.DeepEqual(want.ToJSON(), got.ToJSON()) {
+		t.Errorf("JSON differs:\nreflective: %+v\ngenerated:  %+v", want.ToJSON(), got.ToJSON())
+	}
+	if !reflect.DeepEqual(want.JSONSchema(), got.JSONSchema()) {
+		t.Errorf("JSON Schema differs:\nreflective: %+v\ngenerated:  %+v", want.JSONSchema(), got.JSONSchema())
+	}
+	wantHTML, err := want.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	gotHTML, err := got.HTML()
+	if err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	if wantHTML != gotHTML {
+		t.Errorf("HTML differs:\nreflective:\n%s\ngenerated:\n%s", wantHTML, gotHTML)
+	}
+}
+
+func TestGeneratedFormInitialValues(t *testing.T) {
+	for _, model := range []Signup{
+		{},
+		{Username: "john", Age: 30, Score: 4.25, Level: 3, Plan: "pro", Terms: true, Internal: "x", Token: "abc"},
+	} {
+		reflective, generated := model, model
+		reflectiveForm := core.NewForm(&reflective, http.MethodPost, "signup")
+		generatedForm := NewSignupForm(&generated, http.MethodPost, "signup")
+		assertSameForms(t, reflectiveForm, generatedForm.Form)
+
+		reflectiveForm.ApplyDefaults()
+		generatedForm.ApplyDefaults()
+		assertSameForms(t, reflectiveForm, generatedForm.Form)
+	}
+}
+
+func TestGeneratedFormBind(t *testing.T) {
+	requests := map[string]func() *http.Request{
+		"valid form": func() *http.Request {
+			return newFormRequest(url.Values{
+				"signup_username": {"john"}, "signup_email": {"john@example.com"}, "signup_age": {"42"},
+				"signup_score": {"9.5"}, "signup_level": {"7"}, "signup_plan": {"pro"}, "signup_code": {"ABC"},
+				"signup_terms": {"on"},
+			}, "/?ref=friend")
+		},
+		"invalid form": func() *http.Request {
+			return newFormRequest(url.Values{
+				"signup_username": {"jo"}, "signup_email": {"john"}, "signup_code": {"abc"},
+				"signup_bio": {strings.Repeat("x", 201)},
+			}, "/")
+		},
+		"custom validation": func() *http.Request {
+			return newFormRequest(url.Values{"signup_username": {"admin"}}, "/")
+		},
+		"invalid number": func() *http.Request {
+			return newFormRequest(url.Values{"signup_age": {"old"}}, "/")
+		},
+		"number out of range": func() *http.Request {
+			return newFormRequest(url.Values{"signup_level": {"300"}}, "/")
+		},
+		"json body": func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(
+				`{"username": "john", "email": "john@example.com", "age": 42, "score": 1.5, "terms": true, "code": "XYZ"}`))
+			req.Header.Set("Content-Type", "application/json")
+			return req
+		},
+		"nested json value": func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"username": ["john"]}`))
+			req.Header.Set("Content-Type", "application/json")
+			return req
+		},
+	}
+
+	for name, newRequest := range requests {
+		t.Run(name, func(t *testing.T) {
+			initial := Signup{Username: "old", Age: 5, Internal: "keep"}
+			reflectiveModel, generatedModel := initial, initial
+
+			reflectiveForm, reflectiveErr := bindReflective(&reflectiveModel, newRequest())
+			generatedForm, generatedErr := bindGenerated(&generatedModel, newRequest())
+
+			if (reflectiveErr == nil) != (generatedErr == nil) {
+				t.Fatalf("Errors differ: reflective %v, generated %v", reflectiveErr, generatedErr)
+			}
+			if name == "nested json value" {
+				return // Тексты ошибок encoding/json и сгенерированного кода различаются
+			}
+			if fmt.Sprint(reflectiveErr) != fmt.Sprint(generatedErr) {
+				t.Errorf("Errors differ: reflective %v, generated %v", reflectiveErr, generatedErr)
+			}
+			if reflectiveModel != generatedModel {
+				t.Errorf("Models differ:\nreflective: %+v\ngenerated:  %+v", reflectiveModel, generatedModel)
+			}
+			assertSameForms(t, reflectiveForm, generatedForm)
+		})
+	}
+}
+
+func TestGeneratedSettingsForm(t *testing.T) {
+	reflectiveModel, generatedModel := Settings{Volume: 0.5}, Settings{Volume: 0.5}
+	reflectiveForm := core.NewForm(&reflectiveModel, http.MethodPost, "settings")
+	generatedForm := NewSettingsForm(&generatedModel, http.MethodPost, "settings")
+	assertSameForms(t, reflectiveForm, generatedForm.Form)
+
+	data := url.Values{"settings_theme": {"dark"}, "settings_volume": {"0.75"}, "settings_limit": {"123456"}}
+	reflectiveForm.Bind(newFormRequest(data, "/"))
+	core.UpdateModelFromForm(&reflectiveModel, reflectiveForm)
+	reflectiveForm.Validate(&reflectiveModel)
+	generatedForm.Bind(newFormRequest(data, "/"))
+	generatedForm.Validate()
+
+	if reflectiveModel != generatedModel {
+		t.Errorf("Models differ:\nreflective: %+v\ngenerated:  %+v", reflectiveModel, generatedModel)
+	}
+	assertSameForms(t, reflectiveForm, generatedForm.Form)
+	// Без validate_msg сообщение пустое, но ошибка поля записывается, как и на пути с рефлексией
+	if _, ok := generatedForm.Errs[generatedForm.LimitField().Name]; !ok {
+		t.Error("Expected a max length error for the limit field")
+	}
+}
+
+func newFormRequest(data url.Values, target string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
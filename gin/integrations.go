@@ -0,0 +1,91 @@
+package gin
+
+import (
+	"github.com/DBenyukh/goform/core"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+const (
+	formContextKey  = "form"         // Ключ формы в контексте Gin
+	modelContextKey = "form_model"   // Ключ модели формы в контексте Gin
+	defaultTemplate = "default.html" // Шаблон формы по умолчанию
+)
+
+// ModelFormMiddleware возвращает middleware, создающее новую модель и форму для каждого запроса.
+// T — тип структуры модели, newModel возвращает указатель на новый экземпляр.
+func ModelFormMiddleware[T any](newModel func() *T, method, formID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		model := newModel()
+		r := core.WithPathParamExtractor(c.Request, func(_ *http.Request, name string) string {
+			return c.Param(name)
+		})
+		form, err := core.BindModel(r, model, method, formID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "Invalid form data"})
+			return
+		}
+
+		// Устанавливаем форму и модель в контекст
+		c.Set(formContextKey, form)
+		c.Set(modelContextKey, model)
+		c.Next()
+	}
+}
+
+// GetForm возвращает форму и модель *T текущего запроса.
+// Последнее значение равно false, если middleware не был вызван или тип модели не совпадает.
+func GetForm[T any](c *gin.Context) (*core.Form, *T, bool) {
+	form, ok := c.Value(formContextKey).(*core.Form)
+	if !ok {
+		return nil, nil, false
+	}
+	model, ok := c.Value(modelContextKey).(*T)
+	if !ok {
+		return form, nil, false
+	}
+	return form, model, true
+}
+
+// CSRFMiddleware возвращает middleware для проверки CSRF-токена.
+func CSRFMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := core.VerifyCSRFToken(c.Request); err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": err.Error()})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RenderForm рендерит форму шаблоном по умолчанию через HTML-рендерер Gin и возвращает ошибку рендеринга.
+// CSRF-токен берется из запроса или генерируется автоматически, cookie обновляется.
+// Gin не дает доступа к рендереру из контекста, поэтому переопределения виджетов
+// подключаются через form.SetWidgetTemplates.
+func RenderForm(c *gin.Context, form *core.Form) error {
+	if _, err := core.EnsureCSRFToken(c.Writer, c.Request, form); err != nil {
+		return err
+	}
+
+	// Gin не возвращает ошибку рендеринга, а добавляет ее в c.Errors
+	errorCount := len(c.Errors)
+	c.HTML(http.StatusOK, defaultTemplate, form.ToHTMLResponse())
+	if len(c.Errors) > errorCount {
+		return c.Errors.Last()
+	}
+	return nil
+}
+
+// AddCustomValidationMiddleware возвращает middleware для добавления кастомных правил валидации.
+func AddCustomValidationMiddleware(fieldName string, fn core.ValidationFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		form, ok := c.Value(formContextKey).(*core.Form)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Form not found in context"})
+			return
+		}
+
+		form.AddCustomValidation(fieldName, fn)
+		c.Next()
+	}
+}
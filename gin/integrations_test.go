@@ -0,0 +1,230 @@
+package gin
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DBenyukh/goform/core"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type TestForm struct {
+	Username string `form:"username" validate:"required,min=3" validate_msg:"Username must be at least 3 characters"`
+	Email    string `form:"email" validate:"required,email" validate_msg:"Please provide a valid email address"`
+	Password string `form:"password" validate:"required" validate_msg:"Password is required"`
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newFormRequest(data url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// TestModelFormMiddleware проверяет привязку данных формы к новой модели.
+func TestModelFormMiddleware(t *testing.T) {
+	r := gin.New()
+	r.Use(ModelFormMiddleware(func() *TestForm { return &TestForm{} }, http.MethodPost, "test_form"))
+	r.POST("/", func(c *gin.Context) {
+		_, model, ok := GetForm[TestForm](c)
+		if !ok {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, model)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, newFormRequest(url.Values{"test_form_username": {"testuser"}}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var responseData TestForm
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseData))
+	assert.Equal(t, "testuser", responseData.Username)
+}
+
+// TestCSRFMiddleware проверяет корректность работы CSRFMiddleware.
+func TestCSRFMiddleware(t *testing.T) {
+	r := gin.New()
+	r.Use(CSRFMiddleware())
+	r.POST("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, newFormRequest(url.Values{}))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req := newFormRequest(url.Values{})
+	req.Header.Set(core.CSRFHeaderName, "token123")
+	req.AddCookie(&http.Cookie{Name: core.CSRFCookieName, Value: "token123"})
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestRenderForm проверяет рендеринг формы через HTML-рендерер Gin.
+func TestRenderForm(t *testing.T) {
+	renderer, err := core.NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	r := gin.New()
+	r.HTMLRender = NewHTMLRender(renderer)
+	r.GET("/", func(c *gin.Context) {
+		form := core.NewForm(&TestForm{}, http.MethodPost, "test_form")
+		form.RenderHTML = true
+		assert.NoError(t, RenderForm(c, form))
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "test_form_username")
+	assert.Len(t, rec.Result().Cookies(), 1)
+}
+
+// TestRenderFormError проверяет, что ошибка выполнения шаблона возвращается вызывающему коду.
+func TestRenderFormError(t *testing.T) {
+	renderer, err := core.NewTemplateRendererFS(fstest.MapFS{
+		"default.html": {Data: []byte(`<p>{{ .Missing }}</p>`)},
+	}, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	r := gin.New()
+	r.HTMLRender = NewHTMLRender(renderer)
+	var renderErr error
+	r.GET("/", func(c *gin.Context) {
+		renderErr = RenderForm(c, core.NewForm(&TestForm{}, http.MethodPost, "test_form"))
+		if renderErr != nil {
+			c.String(http.StatusInternalServerError, "render failed")
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Error(t, renderErr)
+	// Ответ еще не записан, поэтому обработчик может выбрать статус и тело сам
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "render failed", rec.Body.String())
+}
+
+// newBrokenDevRenderer возвращает рендерер в режиме разработки, шаблон которого после создания
+// заменен шаблоном с ошибкой разбора: прежний набор шаблонов остается в памяти.
+func newBrokenDevRenderer(t *testing.T) *core.TemplateRenderer {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "default.html")
+	start := time.Now().Add(-time.Hour)
+	if err := os.WriteFile(path, []byte(`<p>{{ .FormID }}</p>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, start, start); err != nil {
+		t.Fatal(err)
+	}
+	renderer, err := core.NewTemplateRenderer(dir, "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+	renderer.DevMode = true
+
+	if err := os.WriteFile(path, []byte(`<p>{{ .FormID </p>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, start.Add(time.Minute), start.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	return renderer
+}
+
+// TestRenderFormDevModeError проверяет, что в режиме разработки ошибка перечитывания шаблонов
+// показывается страницей с ее описанием, а не скрывается прежними шаблонами.
+func TestRenderFormDevModeError(t *testing.T) {
+	r := gin.New()
+	r.HTMLRender = NewHTMLRender(newBrokenDevRenderer(t))
+	var renderErr error
+	r.GET("/", func(c *gin.Context) {
+		renderErr = RenderForm(c, core.NewForm(&TestForm{}, http.MethodPost, "test_form"))
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Error(t, renderErr)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), "<h1>Template error</h1>")
+}
+
+// TestAddCustomValidationMiddleware проверяет добавление кастомных правил валидации.
+func TestAddCustomValidationMiddleware(t *testing.T) {
+	r := gin.New()
+	r.Use(ModelFormMiddleware(func() *TestForm { return &TestForm{} }, http.MethodPost, "test_form"))
+	r.Use(AddCustomValidationMiddleware("username", func(value string) error {
+		if len(value) < 5 {
+			return errors.New("username too short")
+		}
+		return nil
+	}))
+	r.POST("/", func(c *gin.Context) {
+		form, model, _ := GetForm[TestForm](c)
+		if err := form.Validate(model); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	formData := url.Values{
+		"test_form_username": {"user"},
+		"test_form_email":    {"test@example.com"},
+		"test_form_password": {"password"},
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, newFormRequest(formData))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	formData.Set("test_form_username", "longusername")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, newFormRequest(formData))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestModelFormMiddlewarePathParams проверяет привязку параметров маршрута Gin.
+func TestModelFormMiddlewarePathParams(t *testing.T) {
+	type PathForm struct {
+		ID    int    `form:"id" source:"path"`
+		Query string `form:"q" source:"query"`
+	}
+
+	r := gin.New()
+	r.GET("/users/:id", ModelFormMiddleware(func() *PathForm { return &PathForm{} }, http.MethodGet, "search"), func(c *gin.Context) {
+		_, model, _ := GetForm[PathForm](c)
+		c.JSON(http.StatusOK, model)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42?q=golang", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var responseData PathForm
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseData))
+	assert.Equal(t, PathForm{ID: 42, Query: "golang"}, responseData)
+}
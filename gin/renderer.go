@@ -0,0 +1,56 @@
+package gin
+
+import (
+	"bytes"
+	"github.com/DBenyukh/goform/core"
+	"github.com/gin-gonic/gin/render"
+	"net/http"
+)
+
+// HTMLRender адаптирует core.TemplateRenderer к интерфейсу render.HTMLRender.
+type HTMLRender struct {
+	*core.TemplateRenderer
+}
+
+// NewHTMLRender создает HTML-рендерер Gin на основе рендерера шаблонов goform.
+func NewHTMLRender(tr *core.TemplateRenderer) *HTMLRender {
+	return &HTMLRender{TemplateRenderer: tr}
+}
+
+// Instance возвращает рендер шаблона name, используя шаблон по умолчанию, если имя не указано.
+func (r *HTMLRender) Instance(name string, data any) render.Render {
+	if name == "" {
+		name = r.DefaultTemplate
+	}
+	return bufferedHTML{renderer: r.TemplateRenderer, name: name, data: data}
+}
+
+// bufferedHTML рендерит шаблон в буфер и пишет ответ только после успешного выполнения,
+// чтобы ошибка шаблона не оставляла клиенту обрезанную страницу. В режиме разработки
+// ошибка загрузки или выполнения шаблона показывается страницей с ее описанием.
+type bufferedHTML struct {
+	renderer *core.TemplateRenderer
+	name     string
+	data     any
+}
+
+// Render выполняет шаблон и записывает результат в ответ.
+func (r bufferedHTML) Render(w http.ResponseWriter) error {
+	var buf bytes.Buffer
+	if err := r.renderer.Execute(&buf, r.name, r.data); err != nil {
+		if r.renderer.DevMode {
+			r.renderer.RenderError(w, err)
+		}
+		return err
+	}
+	r.WriteContentType(w)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// WriteContentType устанавливает тип содержимого HTML.
+func (r bufferedHTML) WriteContentType(w http.ResponseWriter) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+}
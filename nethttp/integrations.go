@@ -0,0 +1,112 @@
+package nethttp
+
+import (
+	"context"
+	"github.com/DBenyukh/goform/core"
+	"net/http"
+)
+
+// contextKey — тип ключей контекста запроса, используемых пакетом.
+type contextKey int
+
+const (
+	formContextKey  contextKey = iota // Ключ формы в контексте запроса
+	modelContextKey                   // Ключ модели формы в контексте запроса
+)
+
+// ModelFormMiddleware возвращает middleware, создающее новую модель и форму для каждого запроса.
+// T — тип структуры модели, newModel возвращает указатель на новый экземпляр.
+func ModelFormMiddleware[T any](newModel func() *T, method, formID string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			model := newModel()
+			form, err := core.BindModel(r, model, method, formID)
+			if err != nil {
+				http.Error(w, "Invalid form data", http.StatusBadRequest)
+				return
+			}
+
+			// Устанавливаем форму и модель в контекст
+			ctx := context.WithValue(r.Context(), formContextKey, form)
+			ctx = context.WithValue(ctx, modelContextKey, model)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetForm возвращает форму и модель *T текущего запроса.
+// Последнее значение равно false, если middleware не был вызван или тип модели не совпадает.
+func GetForm[T any](r *http.Request) (*core.Form, *T, bool) {
+	form, ok := r.Context().Value(formContextKey).(*core.Form)
+	if !ok {
+		return nil, nil, false
+	}
+	model, ok := r.Context().Value(modelContextKey).(*T)
+	if !ok {
+		return form, nil, false
+	}
+	return form, model, true
+}
+
+// MethodOverrideMiddleware возвращает middleware, заменяющее метод POST-запроса на метод из поля _method
+// или заголовка X-HTTP-Method-Override, если он есть в methods (по умолчанию PUT, PATCH и DELETE).
+// Оборачивайте им весь маршрутизатор, чтобы маршрут выбирался уже по новому методу.
+func MethodOverrideMiddleware(methods ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Method = core.OverrideMethod(r, methods...)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CSRFMiddleware возвращает middleware для проверки CSRF-токена.
+func CSRFMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := core.VerifyCSRFToken(r); err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Render рендерит шаблон name (пустое имя — шаблон по умолчанию) с буферизацией:
+// при ошибке шаблона клиент получает ответ со статусом 500 вместо обрезанной страницы.
+func Render(w http.ResponseWriter, r *http.Request, renderer *core.TemplateRenderer, name string, data any) error {
+	return renderer.Render(w, r, name, data)
+}
+
+// RenderForm рендерит форму шаблоном по умолчанию.
+// CSRF-токен берется из запроса или генерируется автоматически, cookie обновляется.
+func RenderForm(w http.ResponseWriter, r *http.Request, renderer *core.TemplateRenderer, form *core.Form) error {
+	return renderer.RenderForm(w, r, form)
+}
+
+// Respond отвечает данными формы в формате, выбранном core.Negotiate по заголовкам запроса,
+// со статусом 422 при ошибках валидации и 200 в остальных случаях.
+// HTML рендерится шаблоном по умолчанию renderer, а если renderer равен nil — встроенным шаблоном формы.
+func Respond(w http.ResponseWriter, r *http.Request, renderer *core.TemplateRenderer, form *core.Form) error {
+	if renderer == nil {
+		return core.Respond(w, r, form)
+	}
+	return renderer.Respond(w, r, form)
+}
+
+// AddCustomValidationMiddleware возвращает middleware для добавления кастомных правил валидации.
+func AddCustomValidationMiddleware(fieldName string, fn core.ValidationFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			form, ok := r.Context().Value(formContextKey).(*core.Form)
+			if !ok {
+				http.Error(w, "Form not found in context", http.StatusInternalServerError)
+				return
+			}
+
+			form.AddCustomValidation(fieldName, fn)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package nethttp
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DBenyukh/goform/core"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type TestForm struct {
+	Username string `form:"username" validate:"required,min=3" validate_msg:"Username must be at least 3 characters"`
+	Email    string `form:"email" validate:"required,email" validate_msg:"Please provide a valid email address"`
+	Password string `form:"password" validate:"required" validate_msg:"Password is required"`
+	Method   string `form:"-"`
+	FormID   string `form:"-"`
+}
+
+func newFormRequest(data url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// TestModelFormMiddleware проверяет привязку данных формы к новой модели.
+func TestModelFormMiddleware(t *testing.T) {
+	handler := ModelFormMiddleware(func() *TestForm { return &TestForm{} }, http.MethodPost, "test_form")(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			form, model, ok := GetForm[TestForm](r)
+			if !ok {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			assert.Equal(t, "test_form", form.FormID)
+			json.NewEncoder(w).Encode(model)
+		}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newFormRequest(url.Values{
+		"test_form_username": {"testuser"},
+		"test_form_email":    {"test@example.com"},
+	}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var responseData TestForm
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseData))
+	assert.Equal(t, "testuser", responseData.Username)
+	assert.Equal(t, "test@example.com", responseData.Email)
+}
+
+// TestCSRFMiddleware проверяет корректность работы CSRFMiddleware.
+func TestCSRFMiddleware(t *testing.T) {
+	handler := CSRFMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req := newFormRequest(url.Values{"form_id": {"test_form"}, "test_form_csrf_token": {"wrong"}})
+	req.AddCookie(&http.Cookie{Name: core.CSRFCookieName, Value: "token123"})
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = newFormRequest(url.Values{"form_id": {"test_form"}, "test_form_csrf_token": {"token123"}})
+	req.AddCookie(&http.Cookie{Name: core.CSRFCookieName, Value: "token123"})
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestRenderForm проверяет рендеринг формы с автоматическим CSRF-токеном.
+func TestRenderForm(t *testing.T) {
+	renderer, err := core.NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	form := core.NewForm(&TestForm{}, http.MethodPost, "test_form")
+	form.RenderHTML = true
+
+	rec := httptest.NewRecorder()
+	err = RenderForm(rec, httptest.NewRequest(http.MethodGet, "/", nil), renderer, form)
+
+	assert.NoError(t, err)
+	assert.Contains(t, rec.Body.String(), "test_form_username")
+	assert.NotEmpty(t, form.CSRF)
+	assert.Len(t, rec.Result().Cookies(), 1)
+}
+
+// TestRender проверяет рендеринг шаблона по умолчанию.
+func TestRender(t *testing.T) {
+	renderer, err := core.NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	form := core.NewForm(&TestForm{}, http.MethodPost, "test_form")
+	rec := httptest.NewRecorder()
+	err = Render(rec, httptest.NewRequest(http.MethodGet, "/", nil), renderer, "", form.ToHTMLResponse())
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "test_form_username")
+}
+
+// TestRespond проверяет выбор формата ответа и статус при ошибках валидации.
+func TestRespond(t *testing.T) {
+	renderer, err := core.NewTemplateRenderer(filepath.Join("..", "templates"), "default.html")
+	if err != nil {
+		t.Fatalf("Failed to create template renderer: %v", err)
+	}
+
+	form := core.NewForm(&TestForm{}, http.MethodPost, "test_form")
+	form.AddError("username", "Username is required")
+
+	rec := httptest.NewRecorder()
+	assert.NoError(t, Respond(rec, httptest.NewRequest(http.MethodGet, "/", nil), renderer, form))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "test_form_username")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Requested-With", "XMLHttpRequest")
+	rec = httptest.NewRecorder()
+	assert.NoError(t, Respond(rec, req, nil, form))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	responseData, err := core.DecodeFormJSON(rec.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "Username is required", responseData.Errors["username"])
+}
+
+// TestAddCustomValidationMiddleware проверяет добавление кастомных правил валидации.
+func TestAddCustomValidationMiddleware(t *testing.T) {
+	final := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form, model, _ := GetForm[TestForm](r)
+		if err := form.Validate(model); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := ModelFormMiddleware(func() *TestForm { return &TestForm{} }, http.MethodPost, "test_form")(
+		AddCustomValidationMiddleware("username", func(value string) error {
+			if len(value) < 5 {
+				return errors.New("username too short")
+			}
+			return nil
+		})(final))
+
+	formData := url.Values{
+		"test_form_username": {"user"},
+		"test_form_email":    {"test@example.com"},
+		"test_form_password": {"password"},
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newFormRequest(formData))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	formData.Set("test_form_username", "longusername")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newFormRequest(formData))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Без формы в контексте middleware возвращает ошибку
+	rec = httptest.NewRecorder()
+	AddCustomValidationMiddleware("username", nil)(final).ServeHTTP(rec, newFormRequest(formData))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestMethodOverrideMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /users", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("updated"))
+	})
+	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("created"))
+	})
+	handler := MethodOverrideMiddleware()(mux)
+
+	req := newFormRequest(url.Values{core.MethodOverrideField: {"put"}})
+	req.URL.Path = "/users"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "updated", rec.Body.String())
+
+	// Метод вне списка разрешенных не подменяется
+	req = httptest.NewRequest(http.MethodPost, "/users", nil)
+	req.Header.Set(core.MethodOverrideHeader, "CONNECT")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "created", rec.Body.String())
+}
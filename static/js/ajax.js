@@ -7,12 +7,14 @@ document.addEventListener('DOMContentLoaded', function () {
 
             const formData = new FormData(form);
             const formId = form.querySelector('input[name="form_id"]').value;
+            const csrfMeta = document.querySelector('meta[name="csrf-token"]');
 
             fetch(form.action, {
                 method: form.method,
                 headers: {
                     'X-Requested-With': 'XMLHttpRequest',
                     'Content-Type': 'application/x-www-form-urlencoded',
+                    'X-CSRF-Token': csrfMeta ? csrfMeta.content : '',
                 },
                 body: new URLSearchParams(formData),
             })
@@ -38,9 +40,16 @@ document.addEventListener('DOMContentLoaded', function () {
                             input.value = '';
                         });
                     } else if (data.errors) {
+                        // Имена элементов формы берутся из ответа, они зависят от стратегии имен формы
+                        const inputNames = {};
+                        (data.fields || []).forEach(field => {
+                            inputNames[field.name] = field.input_name;
+                        });
+
                         // Отображение новых ошибок
                         Object.keys(data.errors).forEach(field => {
-                            const input = form.querySelector(`[name="${formId}_${field}"]`);
+                            const name = inputNames[field] || `${formId}_${field}`;
+                            const input = form.querySelector(`[name="${CSS.escape(name)}"]`);
                             if (input) {
                                 const errorSpan = document.createElement('span');
                                 errorSpan.className = 'error';